@@ -1,14 +1,270 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
 	"github.com/romanpitatelev/hezzl-goods/internal/app"
 	"github.com/romanpitatelev/hezzl-goods/internal/configs"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/rs/zerolog/log"
+	migrate "github.com/rubenv/sql-migrate"
 )
 
+const usage = `Usage: hezzl-goods [command] [flags]
+
+Commands:
+  serve                       run the REST and gRPC APIs and the webhook dispatcher
+  ingest                      write the audit log from NATS to ClickHouse
+  all                         run serve and ingest in one process (default)
+  migrate status              list the applied and pending Postgres migrations
+  migrate up [n]              apply n or all pending migrations
+  migrate down [n]            roll back n or all applied Postgres migrations
+  migrate redo                roll back and apply again the last Postgres migration
+  config print                print the config with secrets redacted
+  export                      write the goods of a project to a CSV or JSON Lines file
+
+Migrate commands take --dry-run to print the SQL of the Postgres migrations
+instead of running them. ClickHouse migrations only run by up without n
+and are never rolled back.
+
+Export takes -project, -format csv or jsonl, -output (stdout by default) and
+the filters of the goods list: -tags, -tag-mode, -category and repeated
+-attr name=op:value.
+
+Settings are read from defaults, the -config YAML or TOML file, the
+environment and flags, each overriding the previous ones. Run
+"hezzl-goods <command> -h" to list the flags.
+`
+
+var errUsage = errors.New("invalid usage")
+
 func main() {
-	cfg := configs.New()
+	if err := run(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+
+		log.Fatal().Err(err).Msg("service stopped with error")
+	}
+}
+
+func run(args []string) error {
+	command := "all"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+
+	loader := configs.NewLoader("")
+	loader.BindFlags(flags)
+
+	var (
+		dryRun bool
+		export exportFlags
+	)
+
+	switch command {
+	case "migrate":
+		flags.BoolVar(&dryRun, "dry-run", false, "print the SQL of the migrations instead of running them")
+	case "export":
+		export.bind(flags)
+	}
+
+	args, err := parseFlags(flags, args)
+	if err != nil {
+		return fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	switch command {
+	case "serve":
+		return app.Run(loader, app.Roles{API: true}) //nolint:wrapcheck
+	case "ingest":
+		return app.Run(loader, app.Roles{Ingest: true}) //nolint:wrapcheck
+	case "all":
+		return app.Run(loader, app.Roles{API: true, Ingest: true}) //nolint:wrapcheck
+	case "migrate":
+		cfg, err := loader.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		return runMigrate(cfg, args, dryRun)
+	case "config":
+		if len(args) != 1 || args[0] != "print" {
+			fmt.Fprint(os.Stderr, usage)
+
+			return fmt.Errorf("%w: config takes print", errUsage)
+		}
+
+		cfg, err := loader.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		return cfg.Print(os.Stdout) //nolint:wrapcheck
+	case "export":
+		cfg, err := loader.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		return runExport(cfg, export)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+
+		return fmt.Errorf("%w: unknown command %q", errUsage, command)
+	}
+}
+
+// parseFlags allows flags after positional arguments, like "migrate up 2 --dry-run".
+func parseFlags(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func runMigrate(cfg *configs.Config, args []string, dryRun bool) error {
+	ctx := context.Background()
+
+	if len(args) == 0 || len(args) > 2 {
+		fmt.Fprint(os.Stderr, usage)
+
+		return fmt.Errorf("%w: migrate takes status, up [n], down [n] or redo", errUsage)
+	}
+
+	opts := app.MigrateOptions{DryRun: dryRun}
+
+	if len(args) == 2 {
+		limit, err := strconv.Atoi(args[1])
+		if err != nil || limit <= 0 {
+			return fmt.Errorf("%w: invalid number of migrations %q", errUsage, args[1])
+		}
+
+		opts.Limit = limit
+	}
 
-	if err := app.Run(cfg); err != nil {
-		panic(err)
+	switch args[0] {
+	case "up":
+		opts.Direction = migrate.Up
+
+		return app.Migrate(ctx, cfg, opts, os.Stdout) //nolint:wrapcheck
+	case "down":
+		opts.Direction = migrate.Down
+
+		return app.Migrate(ctx, cfg, opts, os.Stdout) //nolint:wrapcheck
+	case "redo":
+		return app.RedoMigration(ctx, cfg, dryRun, os.Stdout) //nolint:wrapcheck
+	case "status":
+		return app.MigrationStatus(ctx, cfg, os.Stdout) //nolint:wrapcheck
+	default:
+		fmt.Fprint(os.Stderr, usage)
+
+		return fmt.Errorf("%w: unknown migrate command %q", errUsage, args[0])
 	}
 }
+
+type exportFlags struct {
+	projectID  int
+	format     string
+	output     string
+	tags       string
+	tagMode    string
+	categoryID int
+	attributes attributeFlags
+}
+
+func (f *exportFlags) bind(flags *flag.FlagSet) {
+	flags.IntVar(&f.projectID, "project", 0, "project of the goods to export")
+	flags.StringVar(&f.format, "format", entity.FormatCSV, "export format, csv or jsonl")
+	flags.StringVar(&f.output, "output", "", "file to write the export to, stdout if empty")
+	flags.StringVar(&f.tags, "tags", "", "comma-separated tags of the goods")
+	flags.StringVar(&f.tagMode, "tag-mode", "", "match any or all of the tags")
+	flags.IntVar(&f.categoryID, "category", 0, "category of the goods, including its subcategories")
+	flags.Var(&f.attributes, "attr", "attribute filter name=op:value, can be repeated")
+}
+
+func (f *exportFlags) request() entity.ExportRequest {
+	request := entity.ExportRequest{
+		Format: f.format,
+		Filter: entity.ListRequest{
+			ProjectID:  f.projectID,
+			CategoryID: f.categoryID,
+			TagMode:    f.tagMode,
+			Attributes: f.attributes,
+		},
+	}
+
+	if f.tags != "" {
+		request.Filter.Tags = strings.Split(f.tags, ",")
+	}
+
+	return request
+}
+
+// attributeFlags collects the repeated -attr flags.
+type attributeFlags []entity.AttributeFilter
+
+func (a *attributeFlags) String() string {
+	filters := make([]string, 0, len(*a))
+	for _, filter := range *a {
+		filters = append(filters, filter.String())
+	}
+
+	return strings.Join(filters, " ")
+}
+
+func (a *attributeFlags) Set(value string) error {
+	name, expression, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("%w: attribute filter %q must be name=op:value", errUsage, value)
+	}
+
+	*a = append(*a, entity.ParseAttributeFilter(name, expression))
+
+	return nil
+}
+
+func runExport(cfg *configs.Config, flags exportFlags) (err error) {
+	w := os.Stdout
+
+	if flags.output != "" {
+		w, err = os.Create(flags.output)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %w", err)
+		}
+
+		defer func() {
+			if closeErr := w.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to close export file: %w", closeErr)
+			}
+		}()
+	}
+
+	exported, err := app.Export(context.Background(), cfg, flags.request(), w)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	log.Info().Msgf("exported %d goods", exported)
+
+	return nil
+}
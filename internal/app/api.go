@@ -0,0 +1,222 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/romanpitatelev/hezzl-goods/internal/configs"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/grpcapi"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest"
+	apikeyshandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/apikeys-handler"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/auth"
+	exporthandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/export-handler"
+	goodshandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/goods-handler"
+	healthhandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/health-handler"
+	ratelimit "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/rate-limit"
+	streamhandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/stream-handler"
+	webhookshandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/webhooks-handler"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/nats/broadcaster"
+	"github.com/romanpitatelev/hezzl-goods/internal/nats/producer"
+	apikeysrepo "github.com/romanpitatelev/hezzl-goods/internal/repository/apikeys-repo"
+	goodsrepo "github.com/romanpitatelev/hezzl-goods/internal/repository/goods-repo"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/redis"
+	webhooksrepo "github.com/romanpitatelev/hezzl-goods/internal/repository/webhooks-repo"
+	apikeysservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/apikeys-service"
+	goodsexport "github.com/romanpitatelev/hezzl-goods/internal/usecase/goods-export"
+	goodsservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/goods-service"
+	healthservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/health-service"
+	webhookdispatcher "github.com/romanpitatelev/hezzl-goods/internal/usecase/webhook-dispatcher"
+	webhooksservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/webhooks-service"
+)
+
+// startAPI starts the components of the API role and returns its REST and gRPC servers.
+//
+//nolint:funlen
+func startAPI(
+	ctx context.Context,
+	cfg *configs.Config,
+	lc *lifecycle,
+	reloader *reloader,
+	nc *nats.Conn,
+	db *postgres.DataStore,
+	redisClient *redis.Client,
+	healthService *healthservice.Service,
+) ([]namedServer, error) {
+	// The broadcaster closes the event streams as soon as shutdown begins, so
+	// the HTTP server doesn't wait for them.
+	goodsBroadcaster := broadcaster.New(nc, "goods.logs")
+	if err := goodsBroadcaster.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start goods broadcaster: %w", err)
+	}
+
+	goodsRepo := goodsrepo.New(goodsrepo.Config{PriorityScope: cfg.PriorityScope}, db)
+	if err := goodsRepo.SetSearchLanguage(entity.ContextWithSystem(ctx), cfg.SearchLanguage); err != nil {
+		return nil, fmt.Errorf("failed to set search language: %w", err)
+	}
+
+	webhooksRepo := webhooksrepo.New(db)
+
+	dispatcher := webhookdispatcher.New(webhookdispatcher.Config{
+		Subject:             "goods.logs",
+		Workers:             cfg.WebhookWorkers,
+		MaxAttempts:         cfg.WebhookMaxAttempts,
+		InitialBackoff:      cfg.WebhookInitialBackoff,
+		MaxBackoff:          cfg.WebhookMaxBackoff,
+		Timeout:             cfg.WebhookTimeout,
+		DisableThreshold:    cfg.WebhookDisableThreshold,
+		AllowPrivateTargets: cfg.WebhookAllowPrivate,
+	}, nc, webhooksRepo)
+
+	if err := lc.start(ctx, component{
+		name:  "webhook dispatcher",
+		start: dispatcher.Start,
+		stop: func(context.Context) error {
+			dispatcher.Wait()
+
+			return nil
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	var natsProducer *producer.NatsWrapper
+
+	if err := lc.start(ctx, component{
+		name: "producer",
+		start: func(ctx context.Context) error {
+			natsProducer = producer.New(ctx, nc, "goods.logs")
+
+			return nil
+		},
+		stop: func(context.Context) error {
+			return natsProducer.Close()
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	healthService.AddOptionalDependency("nats", natsProducer)
+
+	goodsService := goodsservice.New(goodsRepo, natsProducer, redisClient)
+	goodsService.SetCacheConfig(cacheConfig(cfg))
+
+	goodsHandler := goodshandler.New(goodsService)
+
+	streamHandler := streamhandler.New(streamhandler.Config{
+		AllowedOrigins: splitList(cfg.StreamAllowedOrigins),
+	}, goodsBroadcaster)
+
+	exportHandler := exporthandler.New(goodsexport.New(goodsRepo))
+
+	webhooksService := webhooksservice.New(webhooksservice.Config{
+		AllowPrivateTargets: cfg.WebhookAllowPrivate,
+	}, webhooksRepo)
+
+	webhooksHandler := webhookshandler.New(webhooksService)
+
+	apiKeysService := apikeysservice.New(apikeysrepo.New(db), cfg.AdminAPIKey)
+
+	apiKeysHandler := apikeyshandler.New(apiKeysService)
+
+	var tokenVerifier *auth.TokenVerifier
+
+	if cfg.JWKSSource != "" {
+		var err error
+
+		tokenVerifier, err = auth.NewTokenVerifier(ctx, auth.JWTConfig{
+			JWKSSource:      cfg.JWKSSource,
+			Issuer:          cfg.JWTIssuer,
+			Audience:        cfg.JWTAudience,
+			ProjectsClaim:   cfg.JWTProjectsClaim,
+			RolesClaim:      cfg.JWTRolesClaim,
+			RefreshInterval: cfg.JWKSRefresh,
+			RefetchInterval: cfg.JWKSRefetch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create JWT verifier: %w", err)
+		}
+	}
+
+	authMiddleware := auth.New(auth.Config{Enabled: cfg.AuthEnabled}, apiKeysService, tokenVerifier)
+
+	rateLimiter := ratelimit.New(rateLimitConfig(cfg), redisClient)
+
+	reloader.onReload(func(cfg *configs.Config) {
+		goodsService.SetCacheConfig(cacheConfig(cfg))
+		rateLimiter.SetConfig(rateLimitConfig(cfg))
+	})
+
+	healthHandler := healthhandler.New(healthService)
+
+	trustedProxies, err := cfg.TrustedProxyPrefixes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse trusted proxies: %w", err)
+	}
+
+	server := rest.New(
+		rest.Config{
+			BindAddress:    cfg.BindAddress,
+			DrainDelay:     cfg.ShutdownDrainDelay,
+			TrustedProxies: trustedProxies,
+		},
+		goodsHandler,
+		streamHandler,
+		exportHandler,
+		webhooksHandler,
+		apiKeysHandler,
+		authMiddleware,
+		rateLimiter,
+		healthHandler,
+	)
+
+	grpcServer := grpcapi.New(
+		grpcapi.Config{BindAddress: cfg.GRPCBindAddress, AuthEnabled: cfg.AuthEnabled},
+		goodsService,
+		authMiddleware,
+	)
+
+	return []namedServer{
+		{name: "server", server: server},
+		{name: "gRPC server", server: grpcServer},
+	}, nil
+}
+
+func cacheConfig(cfg *configs.Config) goodsservice.CacheConfig {
+	return goodsservice.CacheConfig{
+		GoodTTL:      cfg.CacheGoodTTL,
+		ListTTL:      cfg.CacheListTTL,
+		NotFoundTTL:  cfg.CacheNotFoundTTL,
+		Jitter:       cfg.CacheTTLJitter,
+		MaxValueSize: cfg.CacheMaxValueSize,
+	}
+}
+
+func rateLimitConfig(cfg *configs.Config) ratelimit.Config {
+	return ratelimit.Config{
+		Enabled:     cfg.RateLimitEnabled,
+		Distributed: cfg.RateLimitDistributed,
+		Limits: map[string]ratelimit.Limit{
+			ratelimit.GroupAuth:  {Rate: cfg.RateLimitAuthRPS, Burst: cfg.RateLimitAuthBurst},
+			ratelimit.GroupRead:  {Rate: cfg.RateLimitReadRPS, Burst: cfg.RateLimitReadBurst},
+			ratelimit.GroupWrite: {Rate: cfg.RateLimitWriteRPS, Burst: cfg.RateLimitWriteBurst},
+			ratelimit.GroupAdmin: {Rate: cfg.RateLimitAdminRPS, Burst: cfg.RateLimitAdminBurst},
+		},
+	}
+}
+
+// splitList splits a comma-separated setting and drops empty items.
+func splitList(value string) []string {
+	var items []string
+
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -2,109 +2,144 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os/signal"
 	"syscall"
 
-	"github.com/nats-io/nats.go"
-	"github.com/romanpitatelev/hezzl-goods/internal/configs"
-	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest"
-	goodshandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/goods-handler"
-	"github.com/romanpitatelev/hezzl-goods/internal/nats/consumer"
-	"github.com/romanpitatelev/hezzl-goods/internal/nats/producer"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/admin"
 	"github.com/romanpitatelev/hezzl-goods/internal/repository/clickhouse"
-	goodsrepo "github.com/romanpitatelev/hezzl-goods/internal/repository/goods-repo"
 	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
 	"github.com/romanpitatelev/hezzl-goods/internal/repository/redis"
-	goodsservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/goods-service"
-	"github.com/rs/zerolog"
+	healthservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/health-service"
 	"github.com/rs/zerolog/log"
-	migrate "github.com/rubenv/sql-migrate"
+	"golang.org/x/sync/errgroup"
 )
 
-//nolint:funlen
-func Run(cfg *configs.Config) error {
+const serviceName = "hezzl-goods"
+
+// Roles select the parts of the service Run starts, so they can be scaled
+// independently.
+type Roles struct {
+	// API serves the REST and gRPC APIs and delivers webhooks.
+	API bool
+	// Ingest writes the audit log from NATS to ClickHouse.
+	Ingest bool
+}
+
+type server interface {
+	Run(ctx context.Context) error
+}
+
+type namedServer struct {
+	name   string
+	server server
+}
+
+// Run loads the config, starts the components of the roles and blocks until
+// SIGINT or SIGTERM. SIGHUP reloads the config.
+// On shutdown the servers stop first, then the components in reverse order of
+// start: NATS is drained, the consumer batch is written to ClickHouse, and
+// ClickHouse, Redis and Postgres are closed.
+//
+//nolint:cyclop,funlen
+func Run(loader configLoader, roles Roles) (err error) {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	level, err := zerolog.ParseLevel(cfg.LogLevel)
+	cfg, err := loader.Load()
 	if err != nil {
-		return fmt.Errorf("invalid log level: %w", err)
+		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	log.Level(level)
+	reloader := newReloader(loader)
+	applyLogLevel(cfg)
 
-	db, err := postgres.New(ctx, postgres.Config{Dsn: cfg.PostgresDSN})
-	if err != nil {
-		log.Panic().Err(err).Msg("failed to connect to database")
-	}
+	lc := newLifecycle(cfg.ShutdownTimeout)
 
-	if err := db.Migrate(migrate.Up); err != nil {
-		log.Panic().Err(err).Msg("failed to migrate")
+	defer func() {
+		if stopErr := lc.stop(); stopErr != nil {
+			err = errors.Join(err, stopErr)
+		}
+	}()
+
+	if err := startTracing(ctx, cfg, lc); err != nil {
+		return err
 	}
 
-	log.Info().Msg("successful Postgres migration")
+	healthService := healthservice.New(healthservice.Config{Timeout: cfg.HealthCheckTimeout})
 
-	clickHouseStore, err := clickhouse.New(ctx, clickhouse.Config{Dsn: cfg.ClickHouseDSN})
-	if err != nil {
-		log.Panic().Err(err).Msg("failed to connect to ClickHouse")
-	}
+	var (
+		db          *postgres.DataStore
+		redisClient *redis.Client
+	)
 
-	defer func() {
-		if err := clickHouseStore.Close(); err != nil {
-			log.Warn().Err(err).Msg("failed to close ClickHouse connection")
+	if roles.API {
+		if db, err = startPostgres(ctx, cfg, lc); err != nil {
+			return err
 		}
-	}()
 
-	if err = clickHouseStore.Migrate(migrate.Up); err != nil {
-		log.Panic().Err(err).Msg("failed to migrate ClickHouse")
+		if redisClient, err = startRedis(ctx, cfg, lc); err != nil {
+			return err
+		}
+
+		healthService.AddDependency("postgres", db)
+		healthService.AddOptionalDependency("redis", redisClient)
 	}
 
-	log.Info().Msg("successful ClickHouse migration")
+	var clickHouseStore *clickhouse.Store
 
-	nc, err := nats.Connect(cfg.NATSURL)
-	if err != nil {
-		log.Panic().Err(err).Msg("failed to connect to NATS")
-	}
+	if roles.Ingest {
+		if clickHouseStore, err = startClickHouse(ctx, cfg, lc); err != nil {
+			return err
+		}
 
-	if status := nc.Status(); status != nats.CONNECTED {
-		log.Panic().Msgf("NATS connection status: %v", status)
+		healthService.AddOptionalDependency("clickhouse", clickHouseStore)
 	}
 
-	log.Info().Msg("successful connection to NATS")
+	nc, err := startNATS(ctx, cfg, lc)
+	if err != nil {
+		return err
+	}
 
-	natsProducer := producer.New(ctx, nc, "goods.logs")
+	if roles.Ingest {
+		if err := startConsumer(ctx, cfg, lc, nc, clickHouseStore); err != nil {
+			return err
+		}
+	}
 
-	natsConsumer := consumer.New(ctx, nc, clickHouseStore)
-	if err := natsConsumer.Start(); err != nil {
-		log.Panic().Err(err).Msg("failed to start NATS")
+	servers := []namedServer{
+		{name: "admin server", server: admin.New(admin.Config{BindAddress: cfg.AdminBindAddress})},
 	}
 
-	goodsRepo := goodsrepo.New(db)
+	if roles.API {
+		apiServers, err := startAPI(ctx, cfg, lc, reloader, nc, db, redisClient, healthService)
+		if err != nil {
+			return err
+		}
 
-	redisClient, err := redis.New(ctx, cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
-	if err != nil {
-		log.Panic().Err(err).Msg("failed to connect to Redis")
+		servers = append(servers, apiServers...)
 	}
 
-	defer func() {
-		if err := redisClient.Close(); err != nil {
-			log.Warn().Err(err).Msg("error closing Redis connection")
-		}
-	}()
+	go reloader.run(ctx)
 
-	goodsService := goodsservice.New(goodsRepo, natsProducer, redisClient)
+	group, groupCtx := errgroup.WithContext(ctx)
 
-	goodsHandler := goodshandler.New(goodsService)
+	for _, s := range servers {
+		group.Go(func() error {
+			if err := s.server.Run(groupCtx); err != nil {
+				return fmt.Errorf("failed to run the %s: %w", s.name, err)
+			}
 
-	server := rest.New(
-		rest.Config{BindAddress: cfg.BindAddress},
-		goodsHandler,
-	)
+			return nil
+		})
+	}
 
-	if err := server.Run(ctx); err != nil {
-		return fmt.Errorf("failed to run the server: %w", err)
+	if err := group.Wait(); err != nil {
+		return fmt.Errorf("server stopped with error: %w", err)
 	}
 
+	log.Info().Msg("servers stopped, stopping components")
+
 	return nil
 }
@@ -0,0 +1,237 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/romanpitatelev/hezzl-goods/internal/configs"
+	"github.com/romanpitatelev/hezzl-goods/internal/metrics"
+	"github.com/romanpitatelev/hezzl-goods/internal/nats/consumer"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/clickhouse"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/redis"
+	"github.com/romanpitatelev/hezzl-goods/internal/tracing"
+	"github.com/rs/zerolog/log"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+func startTracing(ctx context.Context, cfg *configs.Config, lc *lifecycle) error {
+	var shutdownTracing func(context.Context) error
+
+	return lc.start(ctx, component{
+		name: "tracing",
+		start: func(ctx context.Context) (err error) {
+			shutdownTracing, err = tracing.Setup(ctx, tracing.Config{
+				Exporter:    cfg.TracingExporter,
+				Endpoint:    cfg.TracingEndpoint,
+				Insecure:    cfg.TracingInsecure,
+				ServiceName: serviceName,
+				SampleRatio: cfg.TracingSampleRatio,
+			})
+
+			return err //nolint:wrapcheck
+		},
+		stop: func(ctx context.Context) error {
+			return shutdownTracing(ctx)
+		},
+	})
+}
+
+func startPostgres(ctx context.Context, cfg *configs.Config, lc *lifecycle) (*postgres.DataStore, error) {
+	var db *postgres.DataStore
+
+	if err := lc.start(ctx, component{
+		name: "postgres",
+		start: func(ctx context.Context) (err error) {
+			db, err = postgres.New(ctx, postgres.Config{Dsn: cfg.PostgresDSN})
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			if !cfg.AutoMigrate {
+				return nil
+			}
+
+			if err := db.Migrate(migrate.Up); err != nil {
+				db.Close()
+
+				return fmt.Errorf("failed to migrate: %w", err)
+			}
+
+			log.Info().Msg("successful Postgres migration")
+
+			return nil
+		},
+		stop: func(context.Context) error {
+			db.Close()
+
+			return nil
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := prometheus.Register(metrics.NewPoolCollector(db)); err != nil {
+		return nil, fmt.Errorf("failed to register pool metrics: %w", err)
+	}
+
+	return db, nil
+}
+
+// startRedis doesn't fail if Redis is unreachable: the client reconnects in the background.
+func startRedis(ctx context.Context, cfg *configs.Config, lc *lifecycle) (*redis.Client, error) {
+	redisClient := redis.Connect(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
+
+	if err := lc.start(ctx, component{
+		name: "redis",
+		start: func(ctx context.Context) error {
+			if err := redisClient.Ping(ctx); err != nil {
+				log.Warn().Err(err).Msg("Redis is unreachable, starting without cache")
+			}
+
+			go redisClient.Monitor(ctx, cfg.ReconnectInterval)
+
+			return nil
+		},
+		stop: func(context.Context) error {
+			return redisClient.Close()
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	return redisClient, nil
+}
+
+// startClickHouse doesn't wait for ClickHouse: the consumer connects to it in the background.
+func startClickHouse(ctx context.Context, cfg *configs.Config, lc *lifecycle) (*clickhouse.Store, error) {
+	var store *clickhouse.Store
+
+	if err := lc.start(ctx, component{
+		name: "clickhouse",
+		start: func(context.Context) (err error) {
+			store, err = clickhouse.Open(clickhouse.Config{Dsn: cfg.ClickHouseDSN})
+
+			return err //nolint:wrapcheck
+		},
+		stop: func(context.Context) error {
+			return store.Close()
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// startNATS doesn't fail if NATS is unreachable: the connection is retried in the background.
+func startNATS(ctx context.Context, cfg *configs.Config, lc *lifecycle) (*nats.Conn, error) {
+	var (
+		nc     *nats.Conn
+		closed = make(chan struct{})
+	)
+
+	if err := lc.start(ctx, component{
+		name: "nats",
+		start: func(context.Context) (err error) {
+			nc, err = nats.Connect(cfg.NATSURL,
+				nats.RetryOnFailedConnect(true),
+				nats.MaxReconnects(-1),
+				nats.ReconnectWait(cfg.ReconnectInterval),
+				nats.ConnectHandler(func(*nats.Conn) {
+					log.Info().Msg("successful connection to NATS")
+				}),
+				nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+					log.Warn().Err(err).Msg("disconnected from NATS, buffering events")
+				}),
+				nats.ReconnectHandler(func(*nats.Conn) {
+					log.Info().Msg("reconnected to NATS")
+				}),
+				nats.ClosedHandler(func(*nats.Conn) {
+					close(closed)
+				}),
+			)
+			if err != nil {
+				return fmt.Errorf("failed to connect to NATS: %w", err)
+			}
+
+			if nc.IsConnected() {
+				log.Info().Msg("successful connection to NATS")
+			} else {
+				log.Warn().Msg("NATS is unreachable, starting in degraded mode")
+			}
+
+			return nil
+		},
+		stop: func(ctx context.Context) error {
+			if !nc.IsConnected() {
+				nc.Close()
+
+				return nil
+			}
+
+			if err := nc.Drain(); err != nil {
+				nc.Close()
+
+				return fmt.Errorf("failed to drain NATS connection: %w", err)
+			}
+
+			select {
+			case <-closed:
+				return nil
+			case <-ctx.Done():
+				nc.Close()
+
+				return ctx.Err() //nolint:wrapcheck
+			}
+		},
+	}); err != nil {
+		return nil, err
+	}
+
+	return nc, nil
+}
+
+// startConsumer subscribes the consumer once ClickHouse is reachable and migrated.
+func startConsumer(ctx context.Context, cfg *configs.Config, lc *lifecycle, nc *nats.Conn, store *clickhouse.Store) error {
+	natsConsumer := consumer.New(ctx, nc, store)
+	started := make(chan struct{})
+
+	return lc.start(ctx, component{
+		name: "consumer",
+		start: func(ctx context.Context) error {
+			go func() {
+				defer close(started)
+
+				startWhenAvailable(ctx, "ClickHouse", cfg.ReconnectInterval, func(ctx context.Context) error {
+					if err := store.Ping(ctx); err != nil {
+						return err //nolint:wrapcheck
+					}
+
+					if cfg.AutoMigrate {
+						if err := store.Migrate(migrate.Up); err != nil {
+							return fmt.Errorf("failed to migrate ClickHouse: %w", err)
+						}
+
+						log.Info().Msg("successful ClickHouse migration")
+					}
+
+					if err := natsConsumer.Start(); err != nil {
+						return fmt.Errorf("failed to start NATS consumer: %w", err)
+					}
+
+					return nil
+				})
+			}()
+
+			return nil
+		},
+		stop: func(ctx context.Context) error {
+			<-started
+
+			return natsConsumer.Stop(ctx)
+		},
+	})
+}
@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// startWhenAvailable calls start every interval until it succeeds or ctx is
+// done. It is used for the dependencies the service can run without.
+func startWhenAvailable(ctx context.Context, name string, interval time.Duration, start func(ctx context.Context) error) {
+	for {
+		err := start(ctx)
+		if err == nil {
+			log.Info().Msgf("%s is available", name)
+
+			return
+		}
+
+		log.Warn().Err(err).Msgf("%s is unavailable, retrying in %s", name, interval)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/configs"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	goodsrepo "github.com/romanpitatelev/hezzl-goods/internal/repository/goods-repo"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+	goodsexport "github.com/romanpitatelev/hezzl-goods/internal/usecase/goods-export"
+)
+
+// Export writes the goods of the request to w and returns their number. The
+// command is run by operators, so it isn't limited to projects.
+func Export(ctx context.Context, cfg *configs.Config, request entity.ExportRequest, w io.Writer) (int, error) {
+	ctx = entity.ContextWithSystem(ctx)
+
+	db, err := postgres.New(ctx, postgres.Config{Dsn: cfg.PostgresDSN})
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	exporter := goodsexport.New(goodsrepo.New(goodsrepo.Config{PriorityScope: cfg.PriorityScope}, db))
+
+	exported, err := exporter.Export(ctx, request, w)
+	if err != nil {
+		return exported, fmt.Errorf("failed to export goods: %w", err)
+	}
+
+	return exported, nil
+}
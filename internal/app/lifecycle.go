@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+var errStopTimeout = errors.New("timed out stopping")
+
+// component is a part of the service with its own start and stop. Both are optional.
+type component struct {
+	name string
+	// start gets a context that is cancelled right before stop is called, so
+	// it can be used by background goroutines.
+	start func(ctx context.Context) error
+	// stop gets a context with the lifecycle stop timeout.
+	stop func(ctx context.Context) error
+}
+
+type startedComponent struct {
+	component
+	cancel context.CancelFunc
+}
+
+// lifecycle starts components one by one and stops them in reverse order, so
+// a component must be started after the components it depends on.
+type lifecycle struct {
+	stopTimeout time.Duration
+	started     []startedComponent
+}
+
+func newLifecycle(stopTimeout time.Duration) *lifecycle {
+	return &lifecycle{stopTimeout: stopTimeout}
+}
+
+// start starts the component. Its context doesn't inherit the cancellation of
+// ctx: components keep running until stop is called.
+func (l *lifecycle) start(ctx context.Context, c component) error {
+	componentCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	if c.start != nil {
+		if err := c.start(componentCtx); err != nil {
+			cancel()
+
+			return fmt.Errorf("failed to start %s: %w", c.name, err)
+		}
+	}
+
+	l.started = append(l.started, startedComponent{component: c, cancel: cancel})
+
+	log.Debug().Msgf("started %s", c.name)
+
+	return nil
+}
+
+// stop stops all started components, even if some of them fail, and returns
+// all errors.
+func (l *lifecycle) stop() error {
+	var errs []error
+
+	for i := len(l.started) - 1; i >= 0; i-- {
+		c := l.started[i]
+
+		c.cancel()
+
+		if c.stop == nil {
+			continue
+		}
+
+		if err := l.stopComponent(c.component); err != nil {
+			log.Error().Err(err).Msgf("failed to stop %s", c.name)
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", c.name, err))
+
+			continue
+		}
+
+		log.Info().Msgf("stopped %s", c.name)
+	}
+
+	l.started = nil
+
+	return errors.Join(errs...)
+}
+
+// stopComponent gives up on the component after the stop timeout, even if its
+// stop doesn't respect the context.
+func (l *lifecycle) stopComponent(c component) error {
+	ctx, cancel := context.WithTimeout(context.Background(), l.stopTimeout)
+	defer cancel()
+
+	stopped := make(chan error, 1)
+
+	go func() {
+		stopped <- c.stop(ctx)
+	}()
+
+	select {
+	case err := <-stopped:
+		return err
+	case <-ctx.Done():
+		return errStopTimeout
+	}
+}
@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/configs"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/clickhouse"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+	"github.com/rs/zerolog/log"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+type MigrateOptions struct {
+	Direction migrate.MigrationDirection
+	// Limit is the number of Postgres migrations to run, all of them if 0.
+	// ClickHouse migrations aren't tracked, so they only run up when Limit is 0.
+	Limit int
+	// DryRun writes the SQL of the Postgres migrations instead of running them.
+	DryRun bool
+}
+
+// Migrate applies or rolls back the Postgres migrations. Migrating up also
+// applies the ClickHouse migrations, which are idempotent. They are never
+// rolled back: ClickHouse keeps the audit log, and without tracking a rollback
+// would drop it.
+func Migrate(ctx context.Context, cfg *configs.Config, opts MigrateOptions, w io.Writer) error {
+	db, err := postgres.New(ctx, postgres.Config{Dsn: cfg.PostgresDSN})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	if opts.DryRun {
+		planned, err := db.PlanMigrations(opts.Direction, opts.Limit)
+		if err != nil {
+			return fmt.Errorf("failed to plan Postgres migrations: %w", err)
+		}
+
+		return writePlan(w, planned)
+	}
+
+	applied, err := db.MigrateMax(ctx, opts.Direction, opts.Limit)
+	if err != nil {
+		return fmt.Errorf("failed to migrate Postgres: %w", err)
+	}
+
+	log.Info().Msgf("applied %d Postgres migrations", applied)
+
+	if opts.Direction != migrate.Up || opts.Limit > 0 {
+		return nil
+	}
+
+	store, err := clickhouse.New(ctx, clickhouse.Config{Dsn: cfg.ClickHouseDSN})
+	if err != nil {
+		return fmt.Errorf("failed to connect to ClickHouse: %w", err)
+	}
+
+	defer func() {
+		if err := store.Close(); err != nil {
+			log.Warn().Err(err).Msg("failed to close ClickHouse connection")
+		}
+	}()
+
+	if err := store.Migrate(migrate.Up); err != nil {
+		return fmt.Errorf("failed to migrate ClickHouse: %w", err)
+	}
+
+	log.Info().Msg("successful ClickHouse migration")
+
+	return nil
+}
+
+// RedoMigration rolls back the last applied Postgres migration and applies it again.
+func RedoMigration(ctx context.Context, cfg *configs.Config, dryRun bool, w io.Writer) error {
+	db, err := postgres.New(ctx, postgres.Config{Dsn: cfg.PostgresDSN})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	if dryRun {
+		planned, err := db.PlanRedo()
+		if err != nil {
+			return fmt.Errorf("failed to plan redo: %w", err)
+		}
+
+		return writePlan(w, planned)
+	}
+
+	id, err := db.RedoMigration(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to redo migration: %w", err)
+	}
+
+	log.Info().Msgf("redone migration %s", id)
+
+	return nil
+}
+
+// MigrationStatus writes the applied and pending Postgres migrations to w.
+// ClickHouse migrations aren't tracked: they are idempotent, all of them run
+// on every migration up and none on a migration down.
+func MigrationStatus(ctx context.Context, cfg *configs.Config, w io.Writer) error {
+	db, err := postgres.New(ctx, postgres.Config{Dsn: cfg.PostgresDSN})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	statuses, err := db.MigrationStatus()
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+
+	for _, status := range statuses {
+		applied := "pending"
+		if status.AppliedAt != nil {
+			applied = "applied " + status.AppliedAt.Format(time.RFC3339)
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", status.ID, applied); err != nil {
+			return fmt.Errorf("failed to write migration status: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func writePlan(w io.Writer, planned []postgres.PlannedMigration) error {
+	for _, migration := range planned {
+		direction := "up"
+		if migration.Direction == migrate.Down {
+			direction = "down"
+		}
+
+		if _, err := fmt.Fprintf(w, "-- %s (%s)\n", migration.ID, direction); err != nil {
+			return fmt.Errorf("failed to write migration plan: %w", err)
+		}
+
+		for _, query := range migration.Queries {
+			if _, err := fmt.Fprintln(w, strings.TrimSpace(query)); err != nil {
+				return fmt.Errorf("failed to write migration plan: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/configs"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type configLoader interface {
+	Load() (*configs.Config, error)
+}
+
+// reloader applies the settings that are safe to change without a restart
+// when the process gets SIGHUP: the log level, the cache TTL and the rate
+// limits. Other settings take effect after a restart.
+type reloader struct {
+	loader   configLoader
+	appliers []func(cfg *configs.Config)
+}
+
+func newReloader(loader configLoader) *reloader {
+	r := &reloader{loader: loader}
+
+	r.onReload(applyLogLevel)
+
+	return r
+}
+
+func (r *reloader) onReload(apply func(cfg *configs.Config)) {
+	r.appliers = append(r.appliers, apply)
+}
+
+func (r *reloader) run(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			r.reload()
+		}
+	}
+}
+
+func (r *reloader) reload() {
+	cfg, err := r.loader.Load()
+	if err != nil {
+		log.Error().Err(err).Msg("failed to reload config, keeping the current one")
+
+		return
+	}
+
+	for _, apply := range r.appliers {
+		apply(cfg)
+	}
+
+	log.Info().Msg("reloaded config")
+}
+
+// applyLogLevel sets the global level. The config is validated, so the level is valid.
+func applyLogLevel(cfg *configs.Config) {
+	level, err := zerolog.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		log.Warn().Err(err).Msg("invalid log level")
+
+		return
+	}
+
+	zerolog.SetGlobalLevel(level)
+}
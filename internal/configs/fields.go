@@ -0,0 +1,94 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var errUnsupportedType = errors.New("unsupported type")
+
+// field is a setting of Config described by its tags.
+type field struct {
+	env          string
+	key          string
+	description  string
+	defaultValue string
+	secret       string
+	value        reflect.Value
+}
+
+func (c *Config) fields() []field {
+	value := reflect.ValueOf(c).Elem()
+	structType := value.Type()
+
+	fields := make([]field, 0, structType.NumField())
+
+	for i := range structType.NumField() {
+		structField := structType.Field(i)
+
+		env := structField.Tag.Get("env")
+		if env == "" {
+			continue
+		}
+
+		fields = append(fields, field{
+			env:          env,
+			key:          structField.Tag.Get("yaml"),
+			description:  structField.Tag.Get("env-description"),
+			defaultValue: structField.Tag.Get("env-default"),
+			secret:       structField.Tag.Get("secret"),
+			value:        value.Field(i),
+		})
+	}
+
+	return fields
+}
+
+//nolint:gochecknoglobals
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func setValue(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q: %w", raw, err)
+		}
+
+		value.SetInt(int64(duration))
+
+		return nil
+	}
+
+	switch value.Kind() { //nolint:exhaustive
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q: %w", raw, err)
+		}
+
+		value.SetBool(parsed)
+	case reflect.Int:
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q: %w", raw, err)
+		}
+
+		value.SetInt(int64(parsed))
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q: %w", raw, err)
+		}
+
+		value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedType, value.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package configs
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+// Print writes the config as YAML that can be used as the config file.
+// Secrets are redacted, and only the password of secret DSNs.
+func (c *Config) Print(w io.Writer) error {
+	for _, f := range c.fields() {
+		if _, err := fmt.Fprintf(w, "%s: %s\n", f.key, formatValue(f)); err != nil {
+			return fmt.Errorf("failed to print config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func formatValue(f field) string {
+	if f.value.Type() == durationType {
+		return strconv.Quote(time.Duration(f.value.Int()).String())
+	}
+
+	if f.value.Kind() != reflect.String {
+		return fmt.Sprint(f.value.Interface())
+	}
+
+	value := f.value.String()
+
+	switch {
+	case value == "":
+	case f.secret == "dsn":
+		value = redactDSN(value)
+	case f.secret != "":
+		value = redacted
+	}
+
+	return strconv.Quote(value)
+}
+
+func redactDSN(dsn string) string {
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return redacted
+	}
+
+	if _, ok := parsed.User.Password(); ok {
+		parsed.User = url.UserPassword(parsed.User.Username(), redacted)
+	}
+
+	return parsed.String()
+}
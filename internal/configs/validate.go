@@ -0,0 +1,104 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/rs/zerolog"
+)
+
+var errInvalidValue = errors.New("invalid value")
+
+// Validate checks the settings and reports all problems at once.
+//
+//nolint:funlen,cyclop
+func (c *Config) Validate() error {
+	var errs []error
+
+	check := func(ok bool, env, problem string) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w of %s: %s", errInvalidValue, env, problem))
+		}
+	}
+
+	_, err := zerolog.ParseLevel(c.LogLevel)
+	check(err == nil && c.LogLevel != "", "LOG_LEVEL", "must be one of trace, debug, info, warn, error, fatal, panic or disabled")
+
+	check(isAddress(c.BindAddress), "BIND_ADDRESS", "must be host:port")
+	check(isAddress(c.GRPCBindAddress), "GRPC_BIND_ADDRESS", "must be host:port")
+	check(isAddress(c.AdminBindAddress), "ADMIN_BIND_ADDRESS", "must be host:port")
+	check(isAddress(c.RedisAddr), "REDIS_ADDR", "must be host:port")
+
+	check(isURL(c.PostgresDSN), "POSTGRES_DSN", "must be a URL")
+	check(isURL(c.ClickHouseDSN), "CLICKHOUSE_DSN", "must be a URL")
+	check(isURL(c.NATSURL), "NATS_URL", "must be a URL")
+
+	check(c.RedisDB >= 0, "REDIS_DB", "must not be negative")
+	check(c.PriorityScope == "project" || c.PriorityScope == "category", "PRIORITY_SCOPE", "must be project or category")
+	check(c.SearchLanguage != "", "SEARCH_LANGUAGE", "must not be empty")
+
+	check(c.CacheGoodTTL > 0, "CACHE_GOOD_TTL", "must be positive")
+	check(c.CacheListTTL > 0, "CACHE_LIST_TTL", "must be positive")
+	check(c.CacheNotFoundTTL >= 0, "CACHE_NOT_FOUND_TTL", "must not be negative")
+	check(c.CacheTTLJitter >= 0 && c.CacheTTLJitter < 1, "CACHE_TTL_JITTER", "must be at least 0 and less than 1")
+	check(c.CacheMaxValueSize >= 0, "CACHE_MAX_VALUE_SIZE", "must not be negative")
+
+	check(c.HealthCheckTimeout > 0, "HEALTH_CHECK_TIMEOUT", "must be positive")
+	check(c.ShutdownDrainDelay >= 0, "SHUTDOWN_DRAIN_DELAY", "must not be negative")
+	check(c.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT", "must be positive")
+	check(c.ReconnectInterval > 0, "RECONNECT_INTERVAL", "must be positive")
+	check(c.JWKSRefresh >= 0, "AUTH_JWKS_REFRESH", "must not be negative")
+	check(c.JWKSRefetch > 0, "AUTH_JWKS_REFETCH", "must be positive")
+
+	check(c.JWKSSource == "" || c.JWTProjectsClaim != "", "AUTH_JWT_PROJECTS_CLAIM", "must be set with AUTH_JWKS")
+	check(c.JWKSSource == "" || c.JWTRolesClaim != "", "AUTH_JWT_ROLES_CLAIM", "must be set with AUTH_JWKS")
+
+	checkLimit := func(rps float64, burst int, name string) {
+		check(rps >= 0, "RATE_LIMIT_"+name+"_RPS", "must not be negative")
+		check(burst >= 0, "RATE_LIMIT_"+name+"_BURST", "must not be negative")
+		check(rps == 0 || burst > 0, "RATE_LIMIT_"+name+"_BURST", "must be positive when the rate is set")
+	}
+
+	_, err = c.TrustedProxyPrefixes()
+	check(err == nil, "TRUSTED_PROXIES", "must be comma-separated IP addresses or CIDRs")
+
+	checkLimit(c.RateLimitAuthRPS, c.RateLimitAuthBurst, "AUTH")
+	checkLimit(c.RateLimitReadRPS, c.RateLimitReadBurst, "READ")
+	checkLimit(c.RateLimitWriteRPS, c.RateLimitWriteBurst, "WRITE")
+	checkLimit(c.RateLimitAdminRPS, c.RateLimitAdminBurst, "ADMIN")
+
+	switch c.TracingExporter {
+	case "none", "otlp", "stdout":
+	default:
+		check(false, "TRACING_EXPORTER", "must be none, otlp or stdout")
+	}
+
+	check(c.TracingSampleRatio >= 0 && c.TracingSampleRatio <= 1, "TRACING_SAMPLE_RATIO", "must be between 0 and 1")
+
+	check(c.WebhookWorkers > 0, "WEBHOOK_WORKERS", "must be positive")
+	check(c.WebhookMaxAttempts > 0, "WEBHOOK_MAX_ATTEMPTS", "must be positive")
+	check(c.WebhookInitialBackoff > 0, "WEBHOOK_INITIAL_BACKOFF", "must be positive")
+	check(c.WebhookMaxBackoff >= c.WebhookInitialBackoff, "WEBHOOK_MAX_BACKOFF", "must not be less than WEBHOOK_INITIAL_BACKOFF")
+	check(c.WebhookTimeout > 0, "WEBHOOK_TIMEOUT", "must be positive")
+	check(c.WebhookDisableThreshold > 0, "WEBHOOK_DISABLE_THRESHOLD", "must be positive")
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidConfig, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func isAddress(address string) bool {
+	_, _, err := net.SplitHostPort(address)
+
+	return err == nil
+}
+
+func isURL(value string) bool {
+	parsed, err := url.Parse(value)
+
+	return err == nil && parsed.Scheme != ""
+}
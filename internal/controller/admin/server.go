@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	readHeaderTimeoutValue = 3 * time.Second
+	timeoutDuration        = 10 * time.Second
+)
+
+type Config struct {
+	BindAddress string
+}
+
+// Server is the operational listener, kept apart from the public API.
+type Server struct {
+	cfg    Config
+	server *http.Server
+	router chi.Router
+}
+
+func New(cfg Config) *Server {
+	router := chi.NewRouter()
+
+	router.Handle("/metrics", promhttp.Handler())
+
+	return &Server{
+		cfg: cfg,
+		server: &http.Server{
+			Addr:              cfg.BindAddress,
+			Handler:           router,
+			ReadHeaderTimeout: readHeaderTimeoutValue,
+		},
+		router: router,
+	}
+}
+
+func (s *Server) Routes() chi.Routes {
+	return s.router
+}
+
+// Run serves until ctx is done and returns once the in-flight requests are finished.
+func (s *Server) Run(ctx context.Context) error {
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		<-ctx.Done()
+
+		gracefulCtx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
+		defer cancel()
+
+		//nolint:contextcheck
+		if err := s.server.Shutdown(gracefulCtx); err != nil {
+			log.Warn().Err(err).Msg("failed to shutdown admin server")
+		}
+	}()
+
+	if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to start an admin server: %w", err)
+	}
+
+	<-stopped
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+
+	goodsv1 "github.com/romanpitatelev/hezzl-goods/api/goods/v1"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	apiKeyMetadataKey        = "x-api-key"
+	authorizationMetadataKey = "authorization"
+)
+
+// methodScopes are the scopes of the goods service RPCs. Other services, such
+// as reflection, only need valid credentials.
+//
+//nolint:gochecknoglobals
+var methodScopes = map[string]string{
+	goodsv1.GoodsService_CreateGood_FullMethodName:      entity.ScopeGoodsWrite,
+	goodsv1.GoodsService_GetGood_FullMethodName:         entity.ScopeGoodsRead,
+	goodsv1.GoodsService_UpdateGood_FullMethodName:      entity.ScopeGoodsWrite,
+	goodsv1.GoodsService_DeleteGood_FullMethodName:      entity.ScopeGoodsWrite,
+	goodsv1.GoodsService_ListGoods_FullMethodName:       entity.ScopeGoodsRead,
+	goodsv1.GoodsService_Reprioritize_FullMethodName:    entity.ScopeGoodsWrite,
+	goodsv1.GoodsService_SetGoodCategory_FullMethodName: entity.ScopeGoodsWrite,
+	goodsv1.GoodsService_StreamGoods_FullMethodName:     entity.ScopeGoodsRead,
+}
+
+type authenticator interface {
+	AuthenticateCredentials(ctx context.Context, authorization, apiKey string) (entity.Principal, error)
+}
+
+// projectRequest is a request naming the project it works with.
+type projectRequest interface {
+	GetProjectId() int64
+}
+
+// authInterceptor authenticates calls with a JWT from the authorization
+// metadata or an API key from x-api-key, like the REST API, and checks the
+// scope of the RPC and the project of the request.
+type authInterceptor struct {
+	enabled       bool
+	authenticator authenticator
+}
+
+func (a *authInterceptor) unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	principal, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	principal, err = authorize(principal, info.FullMethod, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(entity.ContextWithPrincipal(ctx, principal), req)
+}
+
+func (a *authInterceptor) stream(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	principal, err := a.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &authorizedStream{
+		ServerStream: stream,
+		ctx:          entity.ContextWithPrincipal(stream.Context(), principal),
+		principal:    principal,
+		method:       info.FullMethod,
+	})
+}
+
+// authenticate returns the principal of the call metadata or, when
+// authentication is disabled, the anonymous principal.
+func (a *authInterceptor) authenticate(ctx context.Context) (entity.Principal, error) {
+	if !a.enabled {
+		return entity.AnonymousPrincipal(), nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	principal, err := a.authenticator.AuthenticateCredentials(ctx,
+		firstValue(md, authorizationMetadataKey), firstValue(md, apiKeyMetadataKey))
+	if err != nil {
+		return entity.Principal{}, status.Error(codes.Unauthenticated, fmt.Errorf("error authenticating request: %w", err).Error())
+	}
+
+	return principal, nil
+}
+
+func authorize(principal entity.Principal, method string, req any) (entity.Principal, error) {
+	scope, ok := methodScopes[method]
+	if !ok {
+		return principal, nil
+	}
+
+	var projectID int
+
+	if req, ok := req.(projectRequest); ok {
+		projectID = int(req.GetProjectId())
+	}
+
+	principal, err := principal.Authorize(scope, projectID)
+	if err != nil {
+		return entity.Principal{}, status.Error(codes.PermissionDenied, fmt.Errorf("error authorizing request: %w", err).Error())
+	}
+
+	return principal, nil
+}
+
+// authorizedStream checks the project of the request when the handler
+// receives it and limits the principal in the context to that project.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx       context.Context //nolint:containedctx
+	principal entity.Principal
+	method    string
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	principal, err := authorize(s.principal, s.method, m)
+	if err != nil {
+		return err
+	}
+
+	s.ctx = entity.ContextWithPrincipal(s.ServerStream.Context(), principal)
+
+	return nil
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,296 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	goodsv1 "github.com/romanpitatelev/hezzl-goods/api/goods/v1"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultStreamPageSize = 100
+)
+
+type goodsService interface {
+	CreateGood(ctx context.Context, projectID int, req entity.GoodCreateRequest) (entity.Good, error)
+	GetGood(ctx context.Context, id int, projectID int) (entity.Good, error)
+	UpdateGood(ctx context.Context, id int, projectID int, goodUpdate entity.GoodUpdate) (entity.Good, error)
+	DeleteGood(ctx context.Context, id int, projectID int) (entity.GoodDeleteResponse, error)
+	GetGoods(ctx context.Context, request entity.ListRequest) (entity.GoodsListResponse, error)
+	Reprioritize(ctx context.Context, id int, projectID int, newPriority entity.PriorityRequest) (entity.PriorityResponse, error)
+	SetGoodCategory(ctx context.Context, id int, projectID int, req entity.GoodCategoryRequest) (entity.Good, error)
+}
+
+type goodsServer struct {
+	goodsv1.UnimplementedGoodsServiceServer
+
+	goodsService goodsService
+}
+
+func newGoodsServer(goodsService goodsService) *goodsServer {
+	return &goodsServer{
+		goodsService: goodsService,
+	}
+}
+
+func (s *goodsServer) CreateGood(ctx context.Context, req *goodsv1.CreateGoodRequest) (*goodsv1.Good, error) {
+	good, err := s.goodsService.CreateGood(ctx, int(req.GetProjectId()), entity.GoodCreateRequest{
+		Name:        req.GetName(),
+		Description: req.Description,
+		Tags:        req.GetTags(),
+		CategoryID:  fromProtoID(req.CategoryId),
+		Attributes:  req.GetAttributes().AsMap(),
+		ExternalID:  req.ExternalId,
+	})
+	if err != nil {
+		return nil, errorStatus("error creating good", err)
+	}
+
+	return toProtoGood(good), nil
+}
+
+func (s *goodsServer) GetGood(ctx context.Context, req *goodsv1.GetGoodRequest) (*goodsv1.Good, error) {
+	good, err := s.goodsService.GetGood(ctx, int(req.GetId()), int(req.GetProjectId()))
+	if err != nil {
+		return nil, errorStatus("error getting good", err)
+	}
+
+	return toProtoGood(good), nil
+}
+
+func (s *goodsServer) UpdateGood(ctx context.Context, req *goodsv1.UpdateGoodRequest) (*goodsv1.Good, error) {
+	goodUpdate := entity.GoodUpdate{
+		Name:        req.GetName(),
+		Description: req.Description,
+	}
+
+	if req.GetTags() != nil {
+		tags := req.GetTags().GetTags()
+		goodUpdate.Tags = &tags
+	}
+
+	if req.GetAttributes() != nil {
+		goodUpdate.Attributes = req.GetAttributes().AsMap()
+	}
+
+	good, err := s.goodsService.UpdateGood(ctx, int(req.GetId()), int(req.GetProjectId()), goodUpdate)
+	if err != nil {
+		return nil, errorStatus("error updating good", err)
+	}
+
+	return toProtoGood(good), nil
+}
+
+func (s *goodsServer) DeleteGood(ctx context.Context, req *goodsv1.DeleteGoodRequest) (*goodsv1.DeleteGoodResponse, error) {
+	deletedGood, err := s.goodsService.DeleteGood(ctx, int(req.GetId()), int(req.GetProjectId()))
+	if err != nil {
+		return nil, errorStatus("error deleting good", err)
+	}
+
+	return &goodsv1.DeleteGoodResponse{
+		Id:         int64(deletedGood.ID),
+		CampaignId: int64(deletedGood.CampaignID),
+		Removed:    deletedGood.Removed,
+	}, nil
+}
+
+func (s *goodsServer) ListGoods(ctx context.Context, req *goodsv1.ListGoodsRequest) (*goodsv1.ListGoodsResponse, error) {
+	response, err := s.goodsService.GetGoods(ctx, entity.ListRequest{
+		Limit:      int(req.GetLimit()),
+		Offset:     int(req.GetOffset()),
+		ProjectID:  int(req.GetProjectId()),
+		Tags:       req.GetTags(),
+		TagMode:    req.GetTagMode(),
+		CategoryID: int(req.GetCategoryId()),
+		Attributes: fromProtoFilters(req.GetAttributes()),
+	})
+	if err != nil {
+		return nil, errorStatus("error listing goods", err)
+	}
+
+	goods := make([]*goodsv1.Good, 0, len(response.Goods))
+	for _, good := range response.Goods {
+		goods = append(goods, toProtoGood(good))
+	}
+
+	return &goodsv1.ListGoodsResponse{
+		Meta: &goodsv1.Meta{
+			Total:   int64(response.Meta.Total),
+			Removed: int64(response.Meta.Removed),
+			Limit:   int64(response.Meta.Limit),
+			Offset:  int64(response.Meta.Offset),
+		},
+		Goods: goods,
+	}, nil
+}
+
+func (s *goodsServer) Reprioritize(ctx context.Context, req *goodsv1.ReprioritizeRequest) (*goodsv1.ReprioritizeResponse, error) {
+	response, err := s.goodsService.Reprioritize(ctx, int(req.GetId()), int(req.GetProjectId()), entity.PriorityRequest{
+		NewPriority: int(req.GetNewPriority()),
+	})
+	if err != nil {
+		return nil, errorStatus("error reprioritizing good", err)
+	}
+
+	priorities := make([]*goodsv1.Priority, 0, len(response.Priorities))
+	for _, p := range response.Priorities {
+		priorities = append(priorities, &goodsv1.Priority{
+			Id:       int64(p.ID),
+			Priority: int64(p.Priority),
+		})
+	}
+
+	return &goodsv1.ReprioritizeResponse{
+		Priorities: priorities,
+	}, nil
+}
+
+func (s *goodsServer) SetGoodCategory(ctx context.Context, req *goodsv1.SetGoodCategoryRequest) (*goodsv1.Good, error) {
+	good, err := s.goodsService.SetGoodCategory(ctx, int(req.GetId()), int(req.GetProjectId()), entity.GoodCategoryRequest{
+		CategoryID: fromProtoID(req.CategoryId),
+	})
+	if err != nil {
+		return nil, errorStatus("error setting good category", err)
+	}
+
+	return toProtoGood(good), nil
+}
+
+func (s *goodsServer) StreamGoods(req *goodsv1.StreamGoodsRequest, stream goodsv1.GoodsService_StreamGoodsServer) error {
+	pageSize := int(req.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultStreamPageSize
+	}
+
+	ctx := stream.Context()
+
+	for offset := 0; ; offset += pageSize {
+		response, err := s.goodsService.GetGoods(ctx, entity.ListRequest{
+			Limit:      pageSize,
+			Offset:     offset,
+			ProjectID:  int(req.GetProjectId()),
+			Tags:       req.GetTags(),
+			TagMode:    req.GetTagMode(),
+			CategoryID: int(req.GetCategoryId()),
+			Attributes: fromProtoFilters(req.GetAttributes()),
+		})
+		if err != nil {
+			return errorStatus("error streaming goods", err)
+		}
+
+		for _, good := range response.Goods {
+			if err := stream.Send(toProtoGood(good)); err != nil {
+				return fmt.Errorf("failed to send good: %w", err)
+			}
+		}
+
+		if len(response.Goods) < pageSize {
+			return nil
+		}
+	}
+}
+
+func toProtoGood(good entity.Good) *goodsv1.Good {
+	return &goodsv1.Good{
+		Id:          int64(good.ID),
+		ProjectId:   int64(good.ProjectID),
+		Name:        good.Name,
+		Description: good.Description,
+		Priority:    int64(good.Priority),
+		Removed:     good.Removed,
+		CreatedAt:   timestamppb.New(good.CreatedAt),
+		Tags:        good.Tags,
+		CategoryId:  toProtoID(good.CategoryID),
+		Attributes:  toProtoAttributes(good.Attributes),
+		ExternalId:  good.ExternalID,
+	}
+}
+
+func toProtoAttributes(attributes map[string]any) *structpb.Struct {
+	value, err := structpb.NewStruct(attributes)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to convert attributes")
+
+		return nil
+	}
+
+	return value
+}
+
+func fromProtoFilters(filters []*goodsv1.AttributeFilter) []entity.AttributeFilter {
+	result := make([]entity.AttributeFilter, 0, len(filters))
+	for _, filter := range filters {
+		result = append(result, entity.AttributeFilter{
+			Name:     filter.GetName(),
+			Operator: filter.GetOperator(),
+			Value:    filter.GetValue(),
+		})
+	}
+
+	return result
+}
+
+func fromProtoID(id *int64) *int {
+	if id == nil {
+		return nil
+	}
+
+	value := int(*id)
+
+	return &value
+}
+
+func toProtoID(id *int) *int64 {
+	if id == nil {
+		return nil
+	}
+
+	value := int64(*id)
+
+	return &value
+}
+
+func errorStatus(errorText string, err error) error {
+	code := getStatusCode(err)
+	if code == codes.Internal {
+		log.Warn().Err(err).Send()
+
+		return status.Error(code, "internal error")
+	}
+
+	return status.Error(code, fmt.Errorf("%s: %w", errorText, err).Error())
+}
+
+func getStatusCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, entity.ErrGoodNotFound) ||
+		errors.Is(err, entity.ErrCategoryNotFound):
+		return codes.NotFound
+	case errors.Is(err, entity.ErrInvalidIDOrProjectID) ||
+		errors.Is(err, entity.ErrEmptyName) ||
+		errors.Is(err, entity.ErrNegativePriority) ||
+		errors.Is(err, entity.ErrSamePriority) ||
+		errors.Is(err, entity.ErrInvalidTag) ||
+		errors.Is(err, entity.ErrTooManyTags) ||
+		errors.Is(err, entity.ErrInvalidTagMode) ||
+		errors.Is(err, entity.ErrInvalidAttribute) ||
+		errors.Is(err, entity.ErrInvalidAttributeFilter) ||
+		errors.Is(err, entity.ErrCategoryCycle) ||
+		errors.Is(err, entity.ErrInvalidExternalID) ||
+		errors.Is(err, entity.ErrExternalIDRequired):
+		return codes.InvalidArgument
+	case errors.Is(err, entity.ErrExternalIDExists) ||
+		errors.Is(err, entity.ErrTagExists) ||
+		errors.Is(err, entity.ErrCategoryExists):
+		return codes.AlreadyExists
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,64 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const requestIDMetadataKey = "x-request-id"
+
+func requestMetaUnaryInterceptor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	return handler(withRequestMeta(ctx), req)
+}
+
+func requestMetaStreamInterceptor(
+	srv any,
+	stream grpc.ServerStream,
+	_ *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, &requestMetaStream{ServerStream: stream, ctx: withRequestMeta(stream.Context())})
+}
+
+type requestMetaStream struct {
+	grpc.ServerStream
+	ctx context.Context //nolint:containedctx
+}
+
+func (s *requestMetaStream) Context() context.Context {
+	return s.ctx
+}
+
+func withRequestMeta(ctx context.Context) context.Context {
+	var meta entity.RequestMeta
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		meta.ClientIP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(meta.ClientIP); err == nil {
+			meta.ClientIP = host
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("user-agent"); len(values) > 0 {
+		meta.UserAgent = values[0]
+	}
+
+	if values := md.Get(requestIDMetadataKey); len(values) > 0 && values[0] != "" {
+		meta.RequestID = values[0]
+	} else {
+		meta.RequestID = entity.NewRequestID()
+	}
+
+	return entity.ContextWithRequestMeta(ctx, meta)
+}
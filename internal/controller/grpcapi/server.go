@@ -0,0 +1,87 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	goodsv1 "github.com/romanpitatelev/hezzl-goods/api/goods/v1"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+const (
+	timeoutDuration = 10 * time.Second
+)
+
+type Config struct {
+	BindAddress string
+	AuthEnabled bool
+}
+
+type Server struct {
+	cfg    Config
+	server *grpc.Server
+}
+
+// New creates the server. With AuthEnabled every call is authenticated by the
+// authenticator, as in the REST API.
+func New(cfg Config, goodsService goodsService, authenticator authenticator) *Server {
+	auth := &authInterceptor{
+		enabled:       cfg.AuthEnabled,
+		authenticator: authenticator,
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(requestMetaUnaryInterceptor, auth.unary),
+		grpc.ChainStreamInterceptor(requestMetaStreamInterceptor, auth.stream),
+	)
+
+	goodsv1.RegisterGoodsServiceServer(server, newGoodsServer(goodsService))
+	reflection.Register(server)
+
+	return &Server{
+		cfg:    cfg,
+		server: server,
+	}
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.cfg.BindAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.cfg.BindAddress, err)
+	}
+
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		<-ctx.Done()
+
+		graceful := make(chan struct{})
+
+		go func() {
+			s.server.GracefulStop()
+			close(graceful)
+		}()
+
+		select {
+		case <-graceful:
+		case <-time.After(timeoutDuration):
+			log.Warn().Msg("failed to gracefully stop gRPC server, forcing stop")
+			s.server.Stop()
+		}
+	}()
+
+	if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("failed to start a gRPC server: %w", err)
+	}
+
+	<-stopped
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package apikeyshandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+type apiKeysService interface {
+	IssueAPIKey(ctx context.Context, req entity.APIKeyCreateRequest) (entity.APIKey, error)
+	GetAPIKeys(ctx context.Context) (entity.APIKeysListResponse, error)
+	RotateAPIKey(ctx context.Context, id int) (entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+}
+
+type Handler struct {
+	apiKeysService apiKeysService
+}
+
+func New(apiKeysService apiKeysService) *Handler {
+	return &Handler{
+		apiKeysService: apiKeysService,
+	}
+}
+
+func (h *Handler) IssueAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req entity.APIKeyCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	apiKey, err := h.apiKeysService.IssueAPIKey(r.Context(), req)
+	if err != nil {
+		common.ErrorResponse(w, "error issuing api key", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, apiKey)
+}
+
+func (h *Handler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := h.apiKeysService.GetAPIKeys(r.Context())
+	if err != nil {
+		common.ErrorResponse(w, "error listing api keys", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, apiKeys)
+}
+
+func (h *Handler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	apiKey, err := h.apiKeysService.RotateAPIKey(r.Context(), id)
+	if err != nil {
+		common.ErrorResponse(w, "error rotating api key", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, apiKey)
+}
+
+func (h *Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.apiKeysService.RevokeAPIKey(r.Context(), id); err != nil {
+		common.ErrorResponse(w, "error revoking api key", err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+const (
+	APIKeyHeader = "X-API-Key"
+	bearerPrefix = "Bearer "
+)
+
+type apiKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (entity.Principal, error)
+}
+
+type Config struct {
+	Enabled bool
+}
+
+type Middleware struct {
+	cfg     Config
+	apiKeys apiKeyAuthenticator
+	tokens  *TokenVerifier
+}
+
+// New creates the middleware. Bearer tokens are accepted only when tokens is not nil.
+func New(cfg Config, apiKeys apiKeyAuthenticator, tokens *TokenVerifier) *Middleware {
+	return &Middleware{
+		cfg:     cfg,
+		apiKeys: apiKeys,
+		tokens:  tokens,
+	}
+}
+
+// Authenticate puts the caller's principal into the request context. It takes
+// a JWT from the Authorization header or an API key from X-API-Key.
+// Requests without valid credentials are rejected with 401. When
+// authentication is disabled, requests get the anonymous principal.
+func (m *Middleware) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.cfg.Enabled {
+			next.ServeHTTP(w, r.WithContext(entity.ContextWithPrincipal(r.Context(), entity.AnonymousPrincipal())))
+
+			return
+		}
+
+		principal, err := m.AuthenticateCredentials(r.Context(), r.Header.Get("Authorization"), r.Header.Get(APIKeyHeader))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", strings.TrimSpace(bearerPrefix))
+			common.ErrorResponse(w, "error authenticating request", err)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(entity.ContextWithPrincipal(r.Context(), principal)))
+	})
+}
+
+// AuthenticateCredentials returns the principal of a bearer token from the
+// authorization value or, without it, of the API key.
+func (m *Middleware) AuthenticateCredentials(ctx context.Context, authorization, apiKey string) (entity.Principal, error) {
+	if authorization == "" {
+		principal, err := m.apiKeys.Authenticate(ctx, strings.TrimSpace(apiKey))
+		if err != nil {
+			return entity.Principal{}, fmt.Errorf("failed to authenticate api key: %w", err)
+		}
+
+		return principal, nil
+	}
+
+	token, ok := strings.CutPrefix(authorization, bearerPrefix)
+	if !ok || m.tokens == nil {
+		return entity.Principal{}, entity.ErrUnauthorized
+	}
+
+	principal, err := m.tokens.Verify(ctx, strings.TrimSpace(token))
+	if err != nil {
+		return entity.Principal{}, fmt.Errorf("failed to verify token: %w", err)
+	}
+
+	return principal, nil
+}
+
+// Require rejects requests whose principal lacks the scope or access to the
+// project from the projectId query parameter. Principals limited to some
+// projects must always name the project, and their requests are limited to it.
+func (m *Middleware) Require(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !m.cfg.Enabled {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			principal, ok := entity.PrincipalFromContext(r.Context())
+			if !ok {
+				common.ErrorResponse(w, "error authorizing request", entity.ErrUnauthorized)
+
+				return
+			}
+
+			projectID, _ := strconv.Atoi(r.URL.Query().Get("projectId"))
+
+			principal, err := principal.Authorize(scope, projectID)
+			if err != nil {
+				common.ErrorResponse(w, "error authorizing request", err)
+
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(entity.ContextWithPrincipal(r.Context(), principal)))
+		})
+	}
+}
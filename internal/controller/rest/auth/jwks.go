@@ -0,0 +1,249 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jwksFetchTimeout       = 10 * time.Second
+	defaultRefetchInterval = 30 * time.Second
+)
+
+var (
+	errUnsupportedKey = errors.New("unsupported jwk")
+	errKeyNotFound    = errors.New("signing key is not found in jwks")
+	errNoKeys         = errors.New("jwks has no supported signing keys")
+)
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+// keySet holds the public keys of a JWKS loaded from a file or an URL. A token
+// signed with an unknown key makes it fetch the JWKS again, at most once per
+// refetchInterval, so that rotated keys are picked up before the next refresh.
+type keySet struct {
+	source          string
+	httpClient      *http.Client
+	refetchInterval time.Duration
+
+	mu   sync.RWMutex
+	keys map[string]crypto.PublicKey
+
+	fetchMu   sync.Mutex
+	fetchedAt time.Time
+}
+
+func newKeySet(source string, refetchInterval time.Duration) *keySet {
+	if refetchInterval <= 0 {
+		refetchInterval = defaultRefetchInterval
+	}
+
+	return &keySet{
+		source:          source,
+		httpClient:      &http.Client{Timeout: jwksFetchTimeout},
+		refetchInterval: refetchInterval,
+		keys:            make(map[string]crypto.PublicKey),
+	}
+}
+
+func (k *keySet) refresh(ctx context.Context) error {
+	k.fetchMu.Lock()
+	defer k.fetchMu.Unlock()
+
+	return k.fetch(ctx)
+}
+
+// fetch replaces the keys with the ones of the JWKS. Keys that can't be used
+// are skipped, so a new kind of key published by the SSO doesn't break the
+// others. It must be called with fetchMu held.
+func (k *keySet) fetch(ctx context.Context) error {
+	k.fetchedAt = time.Now()
+
+	data, err := k.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	var set jwkSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return fmt.Errorf("failed to parse jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+
+	for _, key := range set.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := key.publicKey()
+		if err != nil {
+			log.Warn().Err(err).Str("kid", key.Kid).Msg("skipping jwk")
+
+			continue
+		}
+
+		keys[key.Kid] = publicKey
+	}
+
+	if len(keys) == 0 {
+		return errNoKeys
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.mu.Unlock()
+
+	return nil
+}
+
+// get returns the key with the kid, fetching the JWKS again when the key is
+// unknown and it wasn't fetched within refetchInterval.
+func (k *keySet) get(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	if key, err := k.lookup(kid); err == nil {
+		return key, nil
+	}
+
+	k.fetchMu.Lock()
+	defer k.fetchMu.Unlock()
+
+	// Another request may have fetched the key while this one was waiting.
+	if key, err := k.lookup(kid); err == nil {
+		return key, nil
+	}
+
+	if time.Since(k.fetchedAt) < k.refetchInterval {
+		return nil, errKeyNotFound
+	}
+
+	if err := k.fetch(ctx); err != nil {
+		log.Warn().Err(err).Msg("failed to fetch jwks for an unknown key")
+	}
+
+	return k.lookup(kid)
+}
+
+func (k *keySet) lookup(kid string) (crypto.PublicKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if key, ok := k.keys[kid]; ok {
+		return key, nil
+	}
+
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, nil
+		}
+	}
+
+	return nil, errKeyNotFound
+}
+
+func (k *keySet) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(k.source, "http://") && !strings.HasPrefix(k.source, "https://") {
+		data, err := os.ReadFile(k.source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwks file: %w", err)
+		}
+
+		return data, nil
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, k.source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jwks request: %w", err)
+	}
+
+	response, err := k.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch jwks: status %d", response.StatusCode)
+	}
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jwks response: %w", err)
+	}
+
+	return data, nil
+}
+
+func (j *jwk) publicKey() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := decodeBigInt(j.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(j.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if j.Crv != "P-256" {
+			return nil, fmt.Errorf("%w: curve %s", errUnsupportedKey, j.Crv)
+		}
+
+		x, err := decodeBigInt(j.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(j.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("%w: key type %s", errUnsupportedKey, j.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode jwk value: %w", err)
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
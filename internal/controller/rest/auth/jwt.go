@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+const allProjects = "*"
+
+var errInvalidClaim = errors.New("invalid claim")
+
+type JWTConfig struct {
+	// JWKSSource is a path to a JWKS file or an http(s) URL.
+	JWKSSource      string
+	Issuer          string
+	Audience        string
+	ProjectsClaim   string
+	RolesClaim      string
+	RefreshInterval time.Duration
+	// RefetchInterval limits fetches of the JWKS for tokens signed with unknown keys.
+	RefetchInterval time.Duration
+}
+
+type TokenVerifier struct {
+	cfg    JWTConfig
+	keys   *keySet
+	parser *jwt.Parser
+}
+
+// NewTokenVerifier loads the JWKS and keeps refreshing it until ctx is done.
+func NewTokenVerifier(ctx context.Context, cfg JWTConfig) (*TokenVerifier, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	v := &TokenVerifier{
+		cfg:    cfg,
+		keys:   newKeySet(cfg.JWKSSource, cfg.RefetchInterval),
+		parser: jwt.NewParser(options...),
+	}
+
+	if err := v.keys.refresh(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load jwks: %w", err)
+	}
+
+	if cfg.RefreshInterval > 0 {
+		go v.refreshLoop(ctx)
+	}
+
+	return v, nil
+}
+
+func (v *TokenVerifier) Verify(ctx context.Context, token string) (entity.Principal, error) {
+	claims := jwt.MapClaims{}
+
+	keyFunc := func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+
+		return v.keys.get(ctx, kid)
+	}
+
+	if _, err := v.parser.ParseWithClaims(token, claims, keyFunc); err != nil {
+		return entity.Principal{}, fmt.Errorf("%w: %w", entity.ErrUnauthorized, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return entity.Principal{}, fmt.Errorf("%w: missing subject", entity.ErrUnauthorized)
+	}
+
+	principal := entity.Principal{
+		ID: "jwt:" + subject,
+	}
+
+	principal.ProjectIDs, principal.AllProjects, err = parseProjects(claims[v.cfg.ProjectsClaim])
+	if err != nil {
+		return entity.Principal{}, fmt.Errorf("%w: %s: %w", entity.ErrUnauthorized, v.cfg.ProjectsClaim, err)
+	}
+
+	principal.Scopes, err = parseRoles(claims[v.cfg.RolesClaim])
+	if err != nil {
+		return entity.Principal{}, fmt.Errorf("%w: %s: %w", entity.ErrUnauthorized, v.cfg.RolesClaim, err)
+	}
+
+	if principal.HasScope(entity.ScopeAdmin) {
+		principal.AllProjects = true
+	}
+
+	return principal, nil
+}
+
+func (v *TokenVerifier) refreshLoop(ctx context.Context) {
+	ticker := time.NewTicker(v.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := v.keys.refresh(ctx); err != nil {
+				log.Warn().Err(err).Msg("failed to refresh jwks")
+			}
+		}
+	}
+}
+
+// parseProjects accepts a list of project IDs as numbers or strings, where "*" grants all projects.
+func parseProjects(claim any) ([]int, bool, error) {
+	if claim == nil {
+		return nil, false, nil
+	}
+
+	values, ok := claim.([]any)
+	if !ok {
+		values = []any{claim}
+	}
+
+	projectIDs := make([]int, 0, len(values))
+
+	for _, value := range values {
+		switch typed := value.(type) {
+		case float64:
+			projectIDs = append(projectIDs, int(typed))
+		case string:
+			if typed == allProjects {
+				return nil, true, nil
+			}
+
+			projectID, err := strconv.Atoi(typed)
+			if err != nil {
+				return nil, false, fmt.Errorf("%w: project %q", errInvalidClaim, typed)
+			}
+
+			projectIDs = append(projectIDs, projectID)
+		default:
+			return nil, false, fmt.Errorf("%w: project %v", errInvalidClaim, value)
+		}
+	}
+
+	return projectIDs, false, nil
+}
+
+// parseRoles accepts a list of roles or a space separated string, like the OAuth scope claim.
+// Roles that are not API scopes are ignored.
+func parseRoles(claim any) ([]string, error) {
+	var roles []string
+
+	switch typed := claim.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		roles = strings.Fields(typed)
+	case []any:
+		for _, value := range typed {
+			role, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: role %v", errInvalidClaim, value)
+			}
+
+			roles = append(roles, role)
+		}
+	default:
+		return nil, fmt.Errorf("%w: roles %v", errInvalidClaim, claim)
+	}
+
+	scopes := make([]string, 0, len(roles))
+
+	for _, role := range roles {
+		if role == entity.ScopeGoodsRead || role == entity.ScopeGoodsWrite || role == entity.ScopeAdmin {
+			scopes = append(scopes, role)
+		}
+	}
+
+	return scopes, nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/romanpitatelev/hezzl-goods/internal/entity"
 	"github.com/rs/zerolog/log"
@@ -44,12 +45,46 @@ func OkResponse(w http.ResponseWriter, status int, response any) {
 
 func getStatusCode(err error) int {
 	switch {
-	case errors.Is(err, entity.ErrGoodNotFound):
+	case errors.Is(err, entity.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, entity.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, entity.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, entity.ErrExternalIDExists) ||
+		errors.Is(err, entity.ErrTagExists) ||
+		errors.Is(err, entity.ErrCategoryExists) ||
+		errors.Is(err, entity.ErrAPIKeyNameExists):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrGoodNotFound) ||
+		errors.Is(err, entity.ErrWebhookNotFound) ||
+		errors.Is(err, entity.ErrAPIKeyNotFound) ||
+		errors.Is(err, entity.ErrTagNotFound) ||
+		errors.Is(err, entity.ErrCategoryNotFound):
 		return http.StatusNotFound
 	case errors.Is(err, entity.ErrInvalidIDOrProjectID) ||
 		errors.Is(err, entity.ErrEmptyName) ||
 		errors.Is(err, entity.ErrNegativePriority) ||
-		errors.Is(err, entity.ErrSamePriority):
+		errors.Is(err, entity.ErrSamePriority) ||
+		errors.Is(err, entity.ErrInvalidWebhookURL) ||
+		errors.Is(err, entity.ErrWebhookTargetNotPublic) ||
+		errors.Is(err, entity.ErrInvalidScope) ||
+		errors.Is(err, entity.ErrEmptyAPIKeyName) ||
+		errors.Is(err, entity.ErrEmptyAPIKeyScopes) ||
+		errors.Is(err, entity.ErrEmptyAPIKeyProjects) ||
+		errors.Is(err, entity.ErrInvalidTag) ||
+		errors.Is(err, entity.ErrTooManyTags) ||
+		errors.Is(err, entity.ErrInvalidTagMode) ||
+		errors.Is(err, entity.ErrCategoryCycle) ||
+		errors.Is(err, entity.ErrEmptyCategoryName) ||
+		errors.Is(err, entity.ErrInvalidAttributeSchema) ||
+		errors.Is(err, entity.ErrInvalidAttribute) ||
+		errors.Is(err, entity.ErrInvalidAttributeFilter) ||
+		errors.Is(err, entity.ErrInvalidSearchQuery) ||
+		errors.Is(err, entity.ErrInvalidFormat) ||
+		errors.Is(err, entity.ErrInvalidImport) ||
+		errors.Is(err, entity.ErrInvalidExternalID) ||
+		errors.Is(err, entity.ErrExternalIDRequired):
 		return http.StatusBadRequest
 	default:
 		return http.StatusInternalServerError
@@ -63,6 +98,53 @@ func GetListRequest(r *http.Request) entity.ListRequest {
 
 	parameters.Limit, _ = strconv.Atoi(queryParams.Get("limit"))
 	parameters.Offset, _ = strconv.Atoi(queryParams.Get("offset"))
+	parameters.ProjectID, _ = strconv.Atoi(queryParams.Get("projectId"))
+	parameters.CategoryID, _ = strconv.Atoi(queryParams.Get("categoryId"))
+	parameters.TagMode = queryParams.Get("tagMode")
+
+	if tags := queryParams.Get("tags"); tags != "" {
+		parameters.Tags = strings.Split(tags, ",")
+	}
+
+	for key, expressions := range queryParams {
+		name, ok := strings.CutPrefix(key, "attr.")
+		if !ok {
+			continue
+		}
+
+		for _, expression := range expressions {
+			parameters.Attributes = append(parameters.Attributes, entity.ParseAttributeFilter(name, expression))
+		}
+	}
+
+	return parameters
+}
+
+func GetSearchRequest(r *http.Request) entity.SearchRequest {
+	queryParams := r.URL.Query()
+
+	parameters := entity.SearchRequest{
+		Query: queryParams.Get("q"),
+	}
+
+	parameters.Limit, _ = strconv.Atoi(queryParams.Get("limit"))
+	parameters.Offset, _ = strconv.Atoi(queryParams.Get("offset"))
+	parameters.ProjectID, _ = strconv.Atoi(queryParams.Get("projectId"))
+
+	return parameters
+}
+
+func GetImportRequest(r *http.Request) entity.ImportRequest {
+	queryParams := r.URL.Query()
+
+	parameters := entity.ImportRequest{
+		Mode:       queryParams.Get("mode"),
+		Format:     queryParams.Get("format"),
+		Priorities: queryParams.Get("priorities"),
+	}
+
+	parameters.ProjectID, _ = strconv.Atoi(queryParams.Get("projectId"))
+	parameters.DryRun, _ = strconv.ParseBool(queryParams.Get("dryRun"))
 
 	return parameters
 }
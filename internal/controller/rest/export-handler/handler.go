@@ -0,0 +1,84 @@
+package exporthandler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+type exporter interface {
+	Export(ctx context.Context, request entity.ExportRequest, w io.Writer) (int, error)
+}
+
+type Handler struct {
+	exporter exporter
+}
+
+func New(exporter exporter) *Handler {
+	return &Handler{
+		exporter: exporter,
+	}
+}
+
+func (h *Handler) Export(w http.ResponseWriter, r *http.Request) {
+	request := entity.ExportRequest{
+		Format: r.URL.Query().Get("format"),
+		Filter: common.GetListRequest(r),
+	}
+
+	if err := request.Validate(); err != nil {
+		common.ErrorResponse(w, "error exporting goods", err)
+
+		return
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if request.Format == entity.FormatJSONL {
+		contentType = "application/x-ndjson"
+	}
+
+	response := &responseWriter{
+		w:           w,
+		contentType: contentType,
+		filename:    fmt.Sprintf("goods-%d.%s", request.Filter.ProjectID, request.Format),
+	}
+
+	if _, err := h.exporter.Export(r.Context(), request, response); err != nil {
+		if !response.started {
+			common.ErrorResponse(w, "error exporting goods", err)
+
+			return
+		}
+
+		// The status is already sent, so abort the response to let the
+		// client know the export is incomplete.
+		log.Warn().Err(err).Msg("export interrupted")
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// responseWriter sends the headers of the export with the first write, so
+// that errors before it still get an error response.
+type responseWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (rw *responseWriter) Write(p []byte) (int, error) {
+	if !rw.started {
+		rw.started = true
+
+		rw.w.Header().Set("Content-Type", rw.contentType)
+		rw.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", rw.filename))
+		rw.w.WriteHeader(http.StatusOK)
+	}
+
+	return rw.w.Write(p) //nolint:wrapcheck
+}
@@ -0,0 +1,53 @@
+package goodshandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (h *Handler) GetAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	schema, err := h.goodsService.GetAttributeSchema(r.Context(), projectID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting attribute schema", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, schema)
+}
+
+func (h *Handler) SetAttributeSchema(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var req entity.AttributeSchemaRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	schema, err := h.goodsService.SetAttributeSchema(r.Context(), projectID, req)
+	if err != nil {
+		common.ErrorResponse(w, "error setting attribute schema", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, schema)
+}
@@ -0,0 +1,146 @@
+package goodshandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (h *Handler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var req entity.CategoryCreateRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	category, err := h.goodsService.CreateCategory(r.Context(), projectID, req)
+	if err != nil {
+		common.ErrorResponse(w, "error creating category", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, category)
+}
+
+func (h *Handler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	categories, err := h.goodsService.GetCategories(r.Context(), projectID)
+	if err != nil {
+		common.ErrorResponse(w, "error listing categories", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, categories)
+}
+
+func (h *Handler) RenameCategory(w http.ResponseWriter, r *http.Request) {
+	urlParams, err := common.GetIDAndProjectID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var req entity.CategoryRenameRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	category, err := h.goodsService.RenameCategory(r.Context(), urlParams.ID, urlParams.ProjectID, req)
+	if err != nil {
+		common.ErrorResponse(w, "error renaming category", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, category)
+}
+
+func (h *Handler) MoveCategory(w http.ResponseWriter, r *http.Request) {
+	urlParams, err := common.GetIDAndProjectID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var req entity.CategoryMoveRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	category, err := h.goodsService.MoveCategory(r.Context(), urlParams.ID, urlParams.ProjectID, req)
+	if err != nil {
+		common.ErrorResponse(w, "error moving category", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, category)
+}
+
+func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	urlParams, err := common.GetIDAndProjectID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := h.goodsService.DeleteCategory(r.Context(), urlParams.ID, urlParams.ProjectID)
+	if err != nil {
+		common.ErrorResponse(w, "error deleting category", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, response)
+}
+
+func (h *Handler) SetGoodCategory(w http.ResponseWriter, r *http.Request) {
+	urlParams, err := common.GetIDAndProjectID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var req entity.GoodCategoryRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	good, err := h.goodsService.SetGoodCategory(r.Context(), urlParams.ID, urlParams.ProjectID, req)
+	if err != nil {
+		common.ErrorResponse(w, "error setting category of good", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, good)
+}
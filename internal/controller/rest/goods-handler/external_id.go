@@ -0,0 +1,58 @@
+package goodshandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (h *Handler) GetGoodByExternalID(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	good, err := h.goodsService.GetGoodByExternalID(r.Context(), r.URL.Query().Get("externalId"), projectID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting good", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, good)
+}
+
+func (h *Handler) UpsertGood(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var req entity.GoodCreateRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	good, created, err := h.goodsService.UpsertGood(r.Context(), projectID, req)
+	if err != nil {
+		common.ErrorResponse(w, "error upserting good", err)
+
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+
+	common.OkResponse(w, status, good)
+}
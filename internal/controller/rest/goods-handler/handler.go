@@ -3,8 +3,10 @@ package goodshandler
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
 	"github.com/romanpitatelev/hezzl-goods/internal/entity"
@@ -17,6 +19,21 @@ type goodsService interface {
 	DeleteGood(ctx context.Context, id int, projectID int) (entity.GoodDeleteResponse, error)
 	GetGoods(ctx context.Context, request entity.ListRequest) (entity.GoodsListResponse, error)
 	Reprioritize(ctx context.Context, id int, projectID int, newPriority entity.PriorityRequest) (entity.PriorityResponse, error)
+	GetTags(ctx context.Context, projectID int) (entity.TagsResponse, error)
+	RenameTag(ctx context.Context, projectID int, req entity.TagRenameRequest) (entity.TagChangeResponse, error)
+	MergeTags(ctx context.Context, projectID int, req entity.TagMergeRequest) (entity.TagChangeResponse, error)
+	CreateCategory(ctx context.Context, projectID int, req entity.CategoryCreateRequest) (entity.Category, error)
+	GetCategories(ctx context.Context, projectID int) (entity.CategoriesResponse, error)
+	RenameCategory(ctx context.Context, id int, projectID int, req entity.CategoryRenameRequest) (entity.Category, error)
+	MoveCategory(ctx context.Context, id int, projectID int, req entity.CategoryMoveRequest) (entity.Category, error)
+	DeleteCategory(ctx context.Context, id int, projectID int) (entity.CategoryDeleteResponse, error)
+	SetGoodCategory(ctx context.Context, id int, projectID int, req entity.GoodCategoryRequest) (entity.Good, error)
+	GetAttributeSchema(ctx context.Context, projectID int) (entity.AttributeSchema, error)
+	SetAttributeSchema(ctx context.Context, projectID int, req entity.AttributeSchemaRequest) (entity.AttributeSchema, error)
+	SearchGoods(ctx context.Context, request entity.SearchRequest) (entity.SearchResponse, error)
+	ImportGoods(ctx context.Context, request entity.ImportRequest, r io.Reader) (entity.ImportResponse, error)
+	GetGoodByExternalID(ctx context.Context, externalID string, projectID int) (entity.Good, error)
+	UpsertGood(ctx context.Context, projectID int, req entity.GoodCreateRequest) (entity.Good, bool, error)
 }
 
 type Handler struct {
@@ -64,7 +81,7 @@ func (h *Handler) GetGood(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 	}
 
-	ctx := r.Context()
+	ctx := withCacheControl(r)
 
 	good, err := h.goodsService.GetGood(ctx, urlParams.ID, urlParams.ProjectID)
 	if err != nil {
@@ -122,7 +139,7 @@ func (h *Handler) DeleteGood(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetGoods(w http.ResponseWriter, r *http.Request) {
 	request := common.GetListRequest(r)
 
-	ctx := r.Context()
+	ctx := withCacheControl(r)
 
 	goods, err := h.goodsService.GetGoods(ctx, request)
 	if err != nil {
@@ -158,3 +175,17 @@ func (h *Handler) Reprioritize(w http.ResponseWriter, r *http.Request) {
 
 	common.OkResponse(w, http.StatusOK, response)
 }
+
+// withCacheControl makes the request skip the cache when the client asks for
+// a fresh value with Cache-Control: no-cache.
+func withCacheControl(r *http.Request) context.Context {
+	for _, header := range r.Header.Values("Cache-Control") {
+		for _, directive := range strings.Split(header, ",") {
+			if strings.EqualFold(strings.TrimSpace(directive), "no-cache") {
+				return entity.ContextWithCacheBypass(r.Context())
+			}
+		}
+	}
+
+	return r.Context()
+}
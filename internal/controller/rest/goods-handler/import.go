@@ -0,0 +1,22 @@
+package goodshandler
+
+import (
+	"net/http"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+)
+
+const maxImportSize = 32 << 20
+
+func (h *Handler) ImportGoods(w http.ResponseWriter, r *http.Request) {
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	response, err := h.goodsService.ImportGoods(r.Context(), common.GetImportRequest(r), body)
+	if err != nil {
+		common.ErrorResponse(w, "error importing goods", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,18 @@
+package goodshandler
+
+import (
+	"net/http"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+)
+
+func (h *Handler) SearchGoods(w http.ResponseWriter, r *http.Request) {
+	response, err := h.goodsService.SearchGoods(r.Context(), common.GetSearchRequest(r))
+	if err != nil {
+		common.ErrorResponse(w, "error searching goods", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,78 @@
+package goodshandler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (h *Handler) GetTags(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	tags, err := h.goodsService.GetTags(r.Context(), projectID)
+	if err != nil {
+		common.ErrorResponse(w, "error listing tags", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, tags)
+}
+
+func (h *Handler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var req entity.TagRenameRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := h.goodsService.RenameTag(r.Context(), projectID, req)
+	if err != nil {
+		common.ErrorResponse(w, "error renaming tag", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, response)
+}
+
+func (h *Handler) MergeTags(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var req entity.TagMergeRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	response, err := h.goodsService.MergeTags(r.Context(), projectID, req)
+	if err != nil {
+		common.ErrorResponse(w, "error merging tags", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, response)
+}
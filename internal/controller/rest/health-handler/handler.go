@@ -0,0 +1,39 @@
+package healthhandler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+type healthService interface {
+	Readiness(ctx context.Context) entity.HealthReport
+}
+
+type Handler struct {
+	healthService healthService
+}
+
+func New(healthService healthService) *Handler {
+	return &Handler{
+		healthService: healthService,
+	}
+}
+
+// Liveness reports that the process is up without checking dependencies.
+func (h *Handler) Liveness(w http.ResponseWriter, _ *http.Request) {
+	common.OkResponse(w, http.StatusOK, entity.HealthReport{Status: entity.HealthStatusOK})
+}
+
+func (h *Handler) Readiness(w http.ResponseWriter, r *http.Request) {
+	report := h.healthService.Readiness(r.Context())
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	common.OkResponse(w, status, report)
+}
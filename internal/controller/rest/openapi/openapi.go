@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	//go:embed openapi.json
+	spec []byte
+
+	//go:embed swagger.html
+	swaggerUI []byte
+)
+
+func Spec() []byte {
+	return spec
+}
+
+func SpecHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(spec); err != nil {
+		log.Warn().Msgf("error writing openapi spec: %v", err)
+	}
+}
+
+func UIHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(swaggerUI); err != nil {
+		log.Warn().Msgf("error writing swagger ui: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	rate    float64
+	burst   float64
+}
+
+func (b *bucket) refill(now time.Time) float64 {
+	return math.Min(b.burst, b.tokens+now.Sub(b.updated).Seconds()*b.rate)
+}
+
+// memoryStore keeps token buckets of a single instance.
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (m *memoryStore) TakeToken(key string, rate float64, burst int) (bool, float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	if now.Sub(m.lastSweep) > sweepInterval {
+		m.sweep(now)
+	}
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(burst), updated: now}
+		m.buckets[key] = b
+	}
+
+	b.rate = rate
+	b.burst = float64(burst)
+	b.tokens = b.refill(now)
+	b.updated = now
+
+	if b.tokens < 1 {
+		return false, b.tokens
+	}
+
+	b.tokens--
+
+	return true, b.tokens
+}
+
+// sweep forgets buckets that have been refilled completely.
+func (m *memoryStore) sweep(now time.Time) {
+	for key, b := range m.buckets {
+		if b.refill(now) >= b.burst {
+			delete(m.buckets, key)
+		}
+	}
+
+	m.lastSweep = now
+}
@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+// Route groups of the REST API. GroupAuth limits all requests by client IP
+// before authentication, so that invalid credentials can't be tried without
+// limit.
+const (
+	GroupAuth  = "auth"
+	GroupRead  = "read"
+	GroupWrite = "write"
+	GroupAdmin = "admin"
+)
+
+const keyPrefix = "ratelimit"
+
+// Limit is a token bucket refilled with Rate tokens per second up to Burst.
+// A zero Rate disables the limit.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+type Config struct {
+	Enabled bool
+	// Distributed keeps the buckets in the shared store instead of memory.
+	Distributed bool
+	Limits      map[string]Limit
+}
+
+// tokenStore keeps the buckets shared by all instances, e.g. in Redis.
+type tokenStore interface {
+	TakeToken(ctx context.Context, key string, rate float64, burst int) (bool, float64, error)
+}
+
+type Limiter struct {
+	cfg         atomic.Pointer[Config]
+	distributed tokenStore
+	local       *memoryStore
+}
+
+// New creates the limiter. In distributed mode the buckets are kept in the
+// shared store, and in memory while the store fails.
+func New(cfg Config, distributed tokenStore) *Limiter {
+	l := &Limiter{
+		distributed: distributed,
+		local:       newMemoryStore(),
+	}
+
+	l.cfg.Store(&cfg)
+
+	return l
+}
+
+// SetConfig replaces the limits. It applies to the next requests.
+func (l *Limiter) SetConfig(cfg Config) {
+	l.cfg.Store(&cfg)
+}
+
+// Limit rejects requests with 429 once the client has spent its bucket for the
+// route group. Clients are told about the limit with X-RateLimit-* headers.
+func (l *Limiter) Limit(group string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cfg := l.cfg.Load()
+
+			limit := cfg.Limits[group]
+			if !cfg.Enabled || limit.Rate <= 0 || limit.Burst <= 0 {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			key := strings.Join([]string{keyPrefix, group, clientKey(r)}, ":")
+
+			allowed, remaining := l.take(r.Context(), cfg.Distributed, key, limit)
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(remaining)))
+			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(secondsUntil(float64(limit.Burst)-remaining, limit.Rate)))
+
+			if !allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(secondsUntil(1-remaining, limit.Rate)))
+				common.ErrorResponse(w, "error limiting request", entity.ErrRateLimited)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (l *Limiter) take(ctx context.Context, distributed bool, key string, limit Limit) (bool, float64) {
+	if distributed && l.distributed != nil {
+		allowed, remaining, err := l.distributed.TakeToken(ctx, key, limit.Rate, limit.Burst)
+		if err == nil {
+			return allowed, remaining
+		}
+
+		log.Warn().Err(err).Msg("failed to take rate limit token, falling back to in-memory limiter")
+	}
+
+	return l.local.TakeToken(key, limit.Rate, limit.Burst)
+}
+
+// clientKey identifies the caller by the authenticated principal, falling back
+// to the client IP. Unverified credentials are ignored, so that clients can't
+// get a fresh bucket by sending another key. The anonymous principal has no ID.
+func clientKey(r *http.Request) string {
+	if principal, ok := entity.PrincipalFromContext(r.Context()); ok && principal.ID != "" {
+		return principal.ID
+	}
+
+	return "ip:" + entity.RequestMetaFromContext(r.Context()).ClientIP
+}
+
+func secondsUntil(tokens, rate float64) int {
+	if tokens <= 0 {
+		return 0
+	}
+
+	return int(math.Ceil(tokens / rate))
+}
@@ -0,0 +1,93 @@
+package requestmeta
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	maxRequestIDLen = 128
+)
+
+type Config struct {
+	// TrustedProxies are the networks of reverse proxies whose X-Forwarded-For
+	// and X-Real-IP headers are honoured.
+	TrustedProxies []netip.Prefix
+}
+
+// New returns the middleware that puts the client IP, user agent and request
+// ID into the request context for the audit log and rate limits. The request
+// ID is taken from X-Request-ID or generated, and is echoed in the response.
+func New(cfg Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := strings.TrimSpace(r.Header.Get(RequestIDHeader))
+			if requestID == "" || len(requestID) > maxRequestIDLen {
+				requestID = entity.NewRequestID()
+			}
+
+			w.Header().Set(RequestIDHeader, requestID)
+
+			meta := entity.RequestMeta{
+				ClientIP:  clientIP(r, cfg.TrustedProxies),
+				UserAgent: r.UserAgent(),
+				RequestID: requestID,
+			}
+
+			next.ServeHTTP(w, r.WithContext(entity.ContextWithRequestMeta(r.Context(), meta)))
+		})
+	}
+}
+
+// clientIP returns the address of the connection unless it comes from a
+// trusted proxy. Then it is the last address in X-Forwarded-For that wasn't
+// added by a trusted proxy, or X-Real-IP.
+func clientIP(r *http.Request, trustedProxies []netip.Prefix) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+
+	if !isTrusted(remote, trustedProxies) {
+		return remote
+	}
+
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if i == 0 || !isTrusted(hop, trustedProxies) {
+				return hop
+			}
+		}
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); realIP != "" {
+		return realIP
+	}
+
+	return remote
+}
+
+func isTrusted(ip string, trustedProxies []netip.Prefix) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
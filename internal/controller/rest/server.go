@@ -5,10 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/netip"
+	"sync/atomic"
 	"time"
 
-	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/openapi"
+	ratelimit "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/rate-limit"
+	requestmeta "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/request-meta"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
 	"github.com/rs/zerolog/log"
 )
 
@@ -19,12 +26,27 @@ const (
 
 type Config struct {
 	BindAddress string
+	// DrainDelay keeps the server running after it reports not ready on
+	// shutdown, so that load balancers stop sending requests first.
+	DrainDelay time.Duration
+	// TrustedProxies are the networks of reverse proxies allowed to report
+	// the client address.
+	TrustedProxies []netip.Prefix
 }
 
 type Server struct {
-	cfg          Config
-	server       *http.Server
-	goodsHandler goodsHandler
+	cfg             Config
+	server          *http.Server
+	router          chi.Router
+	goodsHandler    goodsHandler
+	streamHandler   streamHandler
+	exportHandler   exportHandler
+	webhooksHandler webhooksHandler
+	apiKeysHandler  apiKeysHandler
+	authMiddleware  authMiddleware
+	rateLimiter     rateLimiter
+	healthHandler   healthHandler
+	draining        atomic.Bool
 }
 
 type goodsHandler interface {
@@ -34,11 +56,70 @@ type goodsHandler interface {
 	DeleteGood(w http.ResponseWriter, r *http.Request)
 	GetGoods(w http.ResponseWriter, r *http.Request)
 	Reprioritize(w http.ResponseWriter, r *http.Request)
+	GetTags(w http.ResponseWriter, r *http.Request)
+	RenameTag(w http.ResponseWriter, r *http.Request)
+	MergeTags(w http.ResponseWriter, r *http.Request)
+	CreateCategory(w http.ResponseWriter, r *http.Request)
+	GetCategories(w http.ResponseWriter, r *http.Request)
+	RenameCategory(w http.ResponseWriter, r *http.Request)
+	MoveCategory(w http.ResponseWriter, r *http.Request)
+	DeleteCategory(w http.ResponseWriter, r *http.Request)
+	SetGoodCategory(w http.ResponseWriter, r *http.Request)
+	GetAttributeSchema(w http.ResponseWriter, r *http.Request)
+	SetAttributeSchema(w http.ResponseWriter, r *http.Request)
+	SearchGoods(w http.ResponseWriter, r *http.Request)
+	ImportGoods(w http.ResponseWriter, r *http.Request)
+	GetGoodByExternalID(w http.ResponseWriter, r *http.Request)
+	UpsertGood(w http.ResponseWriter, r *http.Request)
+}
+
+type streamHandler interface {
+	Stream(w http.ResponseWriter, r *http.Request)
+}
+
+type exportHandler interface {
+	Export(w http.ResponseWriter, r *http.Request)
+}
+
+type webhooksHandler interface {
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	GetWebhooks(w http.ResponseWriter, r *http.Request)
+	DeleteWebhook(w http.ResponseWriter, r *http.Request)
+	EnableWebhook(w http.ResponseWriter, r *http.Request)
+	GetDeliveries(w http.ResponseWriter, r *http.Request)
+}
+
+type apiKeysHandler interface {
+	IssueAPIKey(w http.ResponseWriter, r *http.Request)
+	GetAPIKeys(w http.ResponseWriter, r *http.Request)
+	RotateAPIKey(w http.ResponseWriter, r *http.Request)
+	RevokeAPIKey(w http.ResponseWriter, r *http.Request)
+}
+
+type authMiddleware interface {
+	Authenticate(next http.Handler) http.Handler
+	Require(scope string) func(http.Handler) http.Handler
+}
+
+type healthHandler interface {
+	Liveness(w http.ResponseWriter, r *http.Request)
+	Readiness(w http.ResponseWriter, r *http.Request)
+}
+
+type rateLimiter interface {
+	Limit(group string) func(http.Handler) http.Handler
 }
 
 func New(
 	cfg Config,
 	goodsHandler goodsHandler,
+	streamHandler streamHandler,
+	exportHandler exportHandler,
+	webhooksHandler webhooksHandler,
+	apiKeysHandler apiKeysHandler,
+	authMiddleware authMiddleware,
+	rateLimiter rateLimiter,
+	healthHandler healthHandler,
 ) *Server {
 	router := chi.NewRouter()
 	s := &Server{
@@ -48,29 +129,115 @@ func New(
 			Handler:           router,
 			ReadHeaderTimeout: readHeaderTimeoutValue,
 		},
-		goodsHandler: goodsHandler,
+		router:          router,
+		goodsHandler:    goodsHandler,
+		streamHandler:   streamHandler,
+		exportHandler:   exportHandler,
+		webhooksHandler: webhooksHandler,
+		apiKeysHandler:  apiKeysHandler,
+		authMiddleware:  authMiddleware,
+		rateLimiter:     rateLimiter,
+		healthHandler:   healthHandler,
 	}
 
+	router.Use(instrument)
+	router.Use(traceRequests)
+
+	router.Get("/healthz", s.healthHandler.Liveness)
+	router.Get("/readyz", s.readiness)
+
 	router.Route("/api", func(r chi.Router) {
+		r.Get("/openapi.json", openapi.SpecHandler)
+		r.Get("/docs", openapi.UIHandler)
+
 		r.Route("/v1", func(r chi.Router) {
 			r.Use(middleware.Recoverer)
+			r.Use(requestmeta.New(requestmeta.Config{TrustedProxies: s.cfg.TrustedProxies}))
+			r.Use(s.rateLimiter.Limit(ratelimit.GroupAuth))
+			r.Use(s.authMiddleware.Authenticate)
 
-			r.Post("/good/create", s.goodsHandler.CreateGood)
-			r.Get("/good/get", s.goodsHandler.GetGood)
-			r.Patch("/good/update", s.goodsHandler.UpdateGood)
-			r.Delete("/good/remove", s.goodsHandler.DeleteGood)
-			r.Get("/goods/list", s.goodsHandler.GetGoods)
-			r.Patch("/good/reprioritize", s.goodsHandler.Reprioritize)
+			r.Group(func(r chi.Router) {
+				r.Use(s.authMiddleware.Require(entity.ScopeGoodsRead))
+				r.Use(s.rateLimiter.Limit(ratelimit.GroupRead))
+
+				r.Get("/good/get", s.goodsHandler.GetGood)
+				r.Get("/good/by-external-id", s.goodsHandler.GetGoodByExternalID)
+				r.Get("/goods/list", s.goodsHandler.GetGoods)
+				r.Get("/goods/search", s.goodsHandler.SearchGoods)
+				r.Get("/goods/stream", s.streamHandler.Stream)
+				r.Get("/goods/export", s.exportHandler.Export)
+				r.Get("/tags/list", s.goodsHandler.GetTags)
+				r.Get("/categories/list", s.goodsHandler.GetCategories)
+				r.Get("/attributes/schema", s.goodsHandler.GetAttributeSchema)
+				r.Get("/webhooks/list", s.webhooksHandler.GetWebhooks)
+				r.Get("/webhook/deliveries", s.webhooksHandler.GetDeliveries)
+			})
+
+			r.Group(func(r chi.Router) {
+				r.Use(s.authMiddleware.Require(entity.ScopeGoodsWrite))
+				r.Use(s.rateLimiter.Limit(ratelimit.GroupWrite))
+
+				r.Post("/good/create", s.goodsHandler.CreateGood)
+				r.Put("/good/upsert", s.goodsHandler.UpsertGood)
+				r.Patch("/good/update", s.goodsHandler.UpdateGood)
+				r.Delete("/good/remove", s.goodsHandler.DeleteGood)
+				r.Patch("/good/reprioritize", s.goodsHandler.Reprioritize)
+				r.Post("/goods/import", s.goodsHandler.ImportGoods)
+				r.Patch("/tag/rename", s.goodsHandler.RenameTag)
+				r.Post("/tags/merge", s.goodsHandler.MergeTags)
+				r.Patch("/good/category", s.goodsHandler.SetGoodCategory)
+				r.Post("/category/create", s.goodsHandler.CreateCategory)
+				r.Patch("/category/rename", s.goodsHandler.RenameCategory)
+				r.Patch("/category/move", s.goodsHandler.MoveCategory)
+				r.Delete("/category/remove", s.goodsHandler.DeleteCategory)
+				r.Put("/attributes/schema", s.goodsHandler.SetAttributeSchema)
+				r.Post("/webhook/create", s.webhooksHandler.CreateWebhook)
+				r.Delete("/webhook/remove", s.webhooksHandler.DeleteWebhook)
+				r.Patch("/webhook/enable", s.webhooksHandler.EnableWebhook)
+			})
+
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(s.authMiddleware.Require(entity.ScopeAdmin))
+				r.Use(s.rateLimiter.Limit(ratelimit.GroupAdmin))
+
+				r.Post("/apikey/create", s.apiKeysHandler.IssueAPIKey)
+				r.Get("/apikeys/list", s.apiKeysHandler.GetAPIKeys)
+				r.Patch("/apikey/rotate", s.apiKeysHandler.RotateAPIKey)
+				r.Delete("/apikey/revoke", s.apiKeysHandler.RevokeAPIKey)
+			})
 		})
 	})
 
 	return s
 }
 
+func (s *Server) Routes() chi.Routes {
+	return s.router
+}
+
+// readiness reports not ready while the server is draining.
+func (s *Server) readiness(w http.ResponseWriter, r *http.Request) {
+	if s.draining.Load() {
+		common.OkResponse(w, http.StatusServiceUnavailable, entity.HealthReport{Status: entity.HealthStatusDraining})
+
+		return
+	}
+
+	s.healthHandler.Readiness(w, r)
+}
+
+// Run serves until ctx is done and returns once the in-flight requests are finished.
 func (s *Server) Run(ctx context.Context) error {
+	stopped := make(chan struct{})
+
 	go func() {
+		defer close(stopped)
+
 		<-ctx.Done()
 
+		s.draining.Store(true)
+		time.Sleep(s.cfg.DrainDelay)
+
 		gracefulCtx, cancel := context.WithTimeout(context.Background(), timeoutDuration)
 		defer cancel()
 
@@ -84,5 +251,7 @@ func (s *Server) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to start a server: %w", err)
 	}
 
+	<-stopped
+
 	return nil
 }
@@ -0,0 +1,256 @@
+package streamhandler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/nats/broadcaster"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	heartbeatInterval = 15 * time.Second
+	writeTimeout      = 5 * time.Second
+)
+
+type eventSource interface {
+	Subscribe(projectID int, lastEventID uint64) ([]broadcaster.Event, <-chan broadcaster.Event, func())
+}
+
+type Config struct {
+	// AllowedOrigins are the origins besides the service's own that may open
+	// WebSocket streams.
+	AllowedOrigins []string
+}
+
+type Handler struct {
+	eventSource eventSource
+	upgrader    websocket.Upgrader
+}
+
+func New(cfg Config, eventSource eventSource) *Handler {
+	return &Handler{
+		eventSource: eventSource,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: checkOrigin(cfg.AllowedOrigins),
+		},
+	}
+}
+
+// checkOrigin accepts requests of non-browser clients, which send no Origin
+// header, requests from the same host and requests from the allowed origins.
+func checkOrigin(allowed []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+
+		parsed, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+
+		if strings.EqualFold(parsed.Host, r.Host) {
+			return true
+		}
+
+		return slices.ContainsFunc(allowed, func(allowed string) bool {
+			return strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin)
+		})
+	}
+}
+
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil || projectID <= 0 {
+		http.Error(w, entity.ErrInvalidIDOrProjectID.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	lastEventID, err := getLastEventID(r)
+	if err != nil {
+		http.Error(w, "invalid Last-Event-ID", http.StatusBadRequest)
+
+		return
+	}
+
+	if websocket.IsWebSocketUpgrade(r) {
+		h.streamWebSocket(w, r, projectID, lastEventID)
+
+		return
+	}
+
+	h.streamSSE(w, r, projectID, lastEventID)
+}
+
+func (h *Handler) streamSSE(w http.ResponseWriter, r *http.Request, projectID int, lastEventID uint64) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+
+		return
+	}
+
+	replay, events, unsubscribe := h.eventSource.Subscribe(projectID, lastEventID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for _, event := range replay {
+		if err := writeSSEEvent(w, event); err != nil {
+			return
+		}
+	}
+
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			if err := writeSSEEvent(w, event); err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+func (h *Handler) streamWebSocket(w http.ResponseWriter, r *http.Request, projectID int, lastEventID uint64) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to upgrade to websocket")
+
+		return
+	}
+
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Debug().Err(err).Msg("failed to close websocket")
+		}
+	}()
+
+	replay, events, unsubscribe := h.eventSource.Subscribe(projectID, lastEventID)
+	defer unsubscribe()
+
+	clientGone := make(chan struct{})
+
+	go func() {
+		defer close(clientGone)
+
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, event := range replay {
+		if err := writeWebSocketEvent(conn, event); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-clientGone:
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				closeMessage := websocket.FormatCloseMessage(websocket.CloseGoingAway, "stream closed")
+				_ = conn.WriteControl(websocket.CloseMessage, closeMessage, time.Now().Add(writeTimeout))
+
+				return
+			}
+
+			if err := writeWebSocketEvent(conn, event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeSSEEvent(w http.ResponseWriter, event broadcaster.Event) error {
+	if event.Reset {
+		if _, err := fmt.Fprintf(w, "id: %d\nevent: reset\ndata: {}\n\n", event.ID); err != nil {
+			return fmt.Errorf("failed to write event: %w", err)
+		}
+
+		return nil
+	}
+
+	data, err := json.Marshal(event.Log)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Log.Operation, data); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+
+	return nil
+}
+
+func writeWebSocketEvent(conn *websocket.Conn, event broadcaster.Event) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return fmt.Errorf("failed to set write deadline: %w", err)
+	}
+
+	if err := conn.WriteJSON(event); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+
+	return nil
+}
+
+func getLastEventID(r *http.Request) (uint64, error) {
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+
+	if lastEventID == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse last event id: %w", err)
+	}
+
+	return id, nil
+}
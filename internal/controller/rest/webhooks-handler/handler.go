@@ -0,0 +1,125 @@
+package webhookshandler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/common"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+type webhooksService interface {
+	CreateWebhook(ctx context.Context, projectID int, req entity.WebhookCreateRequest) (entity.Webhook, error)
+	GetWebhooks(ctx context.Context, projectID int) (entity.WebhooksListResponse, error)
+	DeleteWebhook(ctx context.Context, id int, projectID int) error
+	EnableWebhook(ctx context.Context, id int, projectID int) (entity.Webhook, error)
+	GetDeliveries(ctx context.Context, id int, projectID int) (entity.WebhookDeliveriesResponse, error)
+}
+
+type Handler struct {
+	webhooksService webhooksService
+}
+
+func New(webhooksService webhooksService) *Handler {
+	return &Handler{
+		webhooksService: webhooksService,
+	}
+}
+
+func (h *Handler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	var req entity.WebhookCreateRequest
+	if err = json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "error decoding request body", http.StatusBadRequest)
+
+		return
+	}
+
+	webhook, err := h.webhooksService.CreateWebhook(r.Context(), projectID, req)
+	if err != nil {
+		common.ErrorResponse(w, "error creating webhook", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusCreated, webhook)
+}
+
+func (h *Handler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	projectID, err := strconv.Atoi(r.URL.Query().Get("projectId"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	webhooks, err := h.webhooksService.GetWebhooks(r.Context(), projectID)
+	if err != nil {
+		common.ErrorResponse(w, "error listing webhooks", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, webhooks)
+}
+
+func (h *Handler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	urlParams, err := common.GetIDAndProjectID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.webhooksService.DeleteWebhook(r.Context(), urlParams.ID, urlParams.ProjectID); err != nil {
+		common.ErrorResponse(w, "error deleting webhook", err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) EnableWebhook(w http.ResponseWriter, r *http.Request) {
+	urlParams, err := common.GetIDAndProjectID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	webhook, err := h.webhooksService.EnableWebhook(r.Context(), urlParams.ID, urlParams.ProjectID)
+	if err != nil {
+		common.ErrorResponse(w, "error enabling webhook", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, webhook)
+}
+
+func (h *Handler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	urlParams, err := common.GetIDAndProjectID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	deliveries, err := h.webhooksService.GetDeliveries(r.Context(), urlParams.ID, urlParams.ProjectID)
+	if err != nil {
+		common.ErrorResponse(w, "error getting webhook deliveries", err)
+
+		return
+	}
+
+	common.OkResponse(w, http.StatusOK, deliveries)
+}
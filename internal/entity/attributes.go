@@ -0,0 +1,286 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxAttributesPerSchema = 64
+
+// Attribute types.
+const (
+	AttributeString  = "string"
+	AttributeInteger = "integer"
+	AttributeNumber  = "number"
+	AttributeBoolean = "boolean"
+)
+
+// Operators of attribute filters.
+const (
+	AttributeOpEq  = "eq"
+	AttributeOpNe  = "ne"
+	AttributeOpGt  = "gt"
+	AttributeOpGte = "gte"
+	AttributeOpLt  = "lt"
+	AttributeOpLte = "lte"
+	AttributeOpIn  = "in"
+)
+
+var attributeNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+type AttributeDefinition struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+	// Enum lists the allowed values of a string attribute.
+	Enum []string `json:"enum,omitempty"`
+	// Min and Max bound the value of a number or integer attribute and the
+	// length of a string attribute.
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+type AttributeSchema struct {
+	ProjectID  int                   `json:"projectId"`
+	Attributes []AttributeDefinition `json:"attributes"`
+	UpdatedAt  *time.Time            `json:"updatedAt"`
+}
+
+type AttributeSchemaRequest struct {
+	Attributes []AttributeDefinition `json:"attributes"`
+}
+
+func (r *AttributeSchemaRequest) Validate() error {
+	if len(r.Attributes) > maxAttributesPerSchema {
+		return fmt.Errorf("%w: at most %d attributes", ErrInvalidAttributeSchema, maxAttributesPerSchema)
+	}
+
+	if r.Attributes == nil {
+		r.Attributes = []AttributeDefinition{}
+	}
+
+	names := make(map[string]bool, len(r.Attributes))
+
+	for _, attribute := range r.Attributes {
+		if !attributeNameRe.MatchString(attribute.Name) {
+			return fmt.Errorf("%w: name %q must be lower-case letters, digits and '_'", ErrInvalidAttributeSchema, attribute.Name)
+		}
+
+		if names[attribute.Name] {
+			return fmt.Errorf("%w: %s is defined twice", ErrInvalidAttributeSchema, attribute.Name)
+		}
+
+		names[attribute.Name] = true
+
+		if err := attribute.validate(); err != nil {
+			return fmt.Errorf("%w: %s %w", ErrInvalidAttributeSchema, attribute.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (a AttributeDefinition) validate() error {
+	switch a.Type {
+	case AttributeString, AttributeInteger, AttributeNumber:
+	case AttributeBoolean:
+		if a.Min != nil || a.Max != nil {
+			return ErrInvalidAttributeBounds
+		}
+	default:
+		return ErrUnknownAttributeType
+	}
+
+	if len(a.Enum) > 0 && a.Type != AttributeString {
+		return ErrInvalidAttributeEnum
+	}
+
+	if a.Min != nil && a.Max != nil && *a.Min > *a.Max {
+		return ErrInvalidAttributeBounds
+	}
+
+	return nil
+}
+
+// ValidateAttributes checks the attributes of a good against the schema and
+// drops the null ones. Numbers of integer attributes must be whole.
+func (s AttributeSchema) ValidateAttributes(attributes map[string]any) error {
+	for name := range attributes {
+		if _, ok := s.attribute(name); !ok {
+			return fmt.Errorf("%w: %s is not in the schema", ErrInvalidAttribute, name)
+		}
+	}
+
+	for _, definition := range s.Attributes {
+		value, ok := attributes[definition.Name]
+		if !ok || value == nil {
+			if definition.Required {
+				return fmt.Errorf("%w: %s is required", ErrInvalidAttribute, definition.Name)
+			}
+
+			delete(attributes, definition.Name)
+
+			continue
+		}
+
+		if err := definition.check(value); err != nil {
+			return fmt.Errorf("%w: %s %w", ErrInvalidAttribute, definition.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (s AttributeSchema) attribute(name string) (AttributeDefinition, bool) {
+	index := slices.IndexFunc(s.Attributes, func(a AttributeDefinition) bool { return a.Name == name })
+	if index < 0 {
+		return AttributeDefinition{}, false
+	}
+
+	return s.Attributes[index], true
+}
+
+// check reports whether the value decoded from JSON has the type of the
+// attribute and is within its enum and bounds.
+func (a AttributeDefinition) check(value any) error {
+	switch a.Type {
+	case AttributeString:
+		s, ok := value.(string)
+		if !ok {
+			return ErrAttributeType
+		}
+
+		if len(a.Enum) > 0 && !slices.Contains(a.Enum, s) {
+			return ErrAttributeNotInEnum
+		}
+
+		return a.checkBounds(float64(utf8.RuneCountInString(s)))
+	case AttributeInteger, AttributeNumber:
+		n, ok := value.(float64)
+		if !ok || (a.Type == AttributeInteger && n != math.Trunc(n)) {
+			return ErrAttributeType
+		}
+
+		return a.checkBounds(n)
+	case AttributeBoolean:
+		if _, ok := value.(bool); !ok {
+			return ErrAttributeType
+		}
+	}
+
+	return nil
+}
+
+func (a AttributeDefinition) checkBounds(n float64) error {
+	if (a.Min != nil && n < *a.Min) || (a.Max != nil && n > *a.Max) {
+		return ErrAttributeOutOfBounds
+	}
+
+	return nil
+}
+
+// AttributeFilter is a filter of the goods list, such as attr.color=eq:red.
+type AttributeFilter struct {
+	Name     string
+	Operator string
+	Value    string
+	// Values are the typed values of Value, set by AttributeSchema.ValidateFilter.
+	// The in operator has several comma-separated values.
+	Values []any
+}
+
+// ParseAttributeFilter splits the expression of the attribute into the operator and the value.
+func ParseAttributeFilter(name, expression string) AttributeFilter {
+	operator, value, _ := strings.Cut(expression, ":")
+
+	return AttributeFilter{
+		Name:     name,
+		Operator: operator,
+		Value:    value,
+	}
+}
+
+func (f AttributeFilter) String() string {
+	return f.Name + "=" + f.Operator + ":" + f.Value
+}
+
+// ValidateFilter checks that the filter is supported by the type of the
+// attribute and sets its typed values. Strings and booleans can only be
+// compared with eq, ne and in.
+func (s AttributeSchema) ValidateFilter(filter *AttributeFilter) error {
+	definition, ok := s.attribute(filter.Name)
+	if !ok {
+		return fmt.Errorf("%w: %s is not in the schema", ErrInvalidAttributeFilter, filter.Name)
+	}
+
+	switch filter.Operator {
+	case AttributeOpEq, AttributeOpNe, AttributeOpIn:
+	case AttributeOpGt, AttributeOpGte, AttributeOpLt, AttributeOpLte:
+		if definition.Type != AttributeInteger && definition.Type != AttributeNumber {
+			return fmt.Errorf("%w: %s can't be compared with %s", ErrInvalidAttributeFilter, filter.Name, filter.Operator)
+		}
+	default:
+		return fmt.Errorf("%w: unknown operator %q", ErrInvalidAttributeFilter, filter.Operator)
+	}
+
+	values := []string{filter.Value}
+	if filter.Operator == AttributeOpIn {
+		values = strings.Split(filter.Value, ",")
+	}
+
+	filter.Values = make([]any, 0, len(values))
+
+	for _, value := range values {
+		typed, err := definition.parse(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s %w", ErrInvalidAttributeFilter, filter.Name, err)
+		}
+
+		filter.Values = append(filter.Values, typed)
+	}
+
+	return nil
+}
+
+// ValidateFilters validates the filters and sorts them, so that equal
+// filters in any order are the same.
+func (s AttributeSchema) ValidateFilters(filters []AttributeFilter) error {
+	for i := range filters {
+		if err := s.ValidateFilter(&filters[i]); err != nil {
+			return err
+		}
+	}
+
+	slices.SortFunc(filters, func(a, b AttributeFilter) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return nil
+}
+
+func (a AttributeDefinition) parse(value string) (any, error) {
+	switch a.Type {
+	case AttributeInteger, AttributeNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, ErrAttributeType
+		}
+
+		return n, nil
+	case AttributeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, ErrAttributeType
+		}
+
+		return b, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const requestIDLength = 16
+
+// RequestMeta describes where a request came from, for the audit log.
+type RequestMeta struct {
+	ClientIP  string
+	UserAgent string
+	RequestID string
+}
+
+type requestMetaCtxKey struct{}
+
+func ContextWithRequestMeta(ctx context.Context, meta RequestMeta) context.Context {
+	return context.WithValue(ctx, requestMetaCtxKey{}, meta)
+}
+
+func RequestMetaFromContext(ctx context.Context) RequestMeta {
+	meta, _ := ctx.Value(requestMetaCtxKey{}).(RequestMeta)
+
+	return meta
+}
+
+func NewRequestID() string {
+	buf := make([]byte, requestIDLength)
+	_, _ = rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
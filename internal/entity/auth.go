@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+const (
+	ScopeGoodsRead  = "goods:read"
+	ScopeGoodsWrite = "goods:write"
+	ScopeAdmin      = "admin"
+)
+
+type APIKey struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Key        string     `json:"key,omitempty"`
+	Prefix     string     `json:"prefix"`
+	ProjectIDs []int      `json:"projectIds"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	RotatedAt  *time.Time `json:"rotatedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+type APIKeyCreateRequest struct {
+	Name       string   `json:"name"`
+	ProjectIDs []int    `json:"projectIds"`
+	Scopes     []string `json:"scopes"`
+}
+
+// Validate requires a scope and, except for admin keys that access all
+// projects, a project.
+func (a *APIKeyCreateRequest) Validate() error {
+	if a.Name == "" {
+		return ErrEmptyAPIKeyName
+	}
+
+	if len(a.Scopes) == 0 {
+		return ErrEmptyAPIKeyScopes
+	}
+
+	if len(a.ProjectIDs) == 0 && !slices.Contains(a.Scopes, ScopeAdmin) {
+		return ErrEmptyAPIKeyProjects
+	}
+
+	for _, scope := range a.Scopes {
+		if scope != ScopeGoodsRead && scope != ScopeGoodsWrite && scope != ScopeAdmin {
+			return ErrInvalidScope
+		}
+	}
+
+	for _, projectID := range a.ProjectIDs {
+		if projectID <= 0 {
+			return ErrInvalidIDOrProjectID
+		}
+	}
+
+	return nil
+}
+
+type APIKeysListResponse struct {
+	APIKeys []APIKey `json:"apiKeys"`
+}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	ID          string
+	ProjectIDs  []int
+	Scopes      []string
+	AllProjects bool
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	if slices.Contains(p.Scopes, ScopeAdmin) {
+		return true
+	}
+
+	if scope == ScopeGoodsRead && slices.Contains(p.Scopes, ScopeGoodsWrite) {
+		return true
+	}
+
+	return slices.Contains(p.Scopes, scope)
+}
+
+func (p *Principal) CanAccessProject(projectID int) bool {
+	return p.AllProjects || slices.Contains(p.ProjectIDs, projectID)
+}
+
+// Authorize checks the scope and, for principals limited to some projects, the
+// project of the request. It returns the principal limited to that project.
+func (p *Principal) Authorize(scope string, projectID int) (Principal, error) {
+	if !p.HasScope(scope) {
+		return Principal{}, ErrForbidden
+	}
+
+	principal := *p
+
+	if !principal.AllProjects {
+		if !principal.CanAccessProject(projectID) {
+			return Principal{}, ErrForbidden
+		}
+
+		principal.ProjectIDs = []int{projectID}
+	}
+
+	return principal, nil
+}
+
+// AnonymousPrincipal is the principal of requests when authentication is
+// disabled. It has every scope in all projects.
+func AnonymousPrincipal() Principal {
+	return Principal{
+		Scopes:      []string{ScopeAdmin},
+		AllProjects: true,
+	}
+}
+
+type principalCtxKey struct{}
+
+type systemCtxKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalCtxKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalCtxKey{}).(Principal)
+
+	return principal, ok
+}
+
+// ContextWithSystem marks work done by the service itself rather than for a
+// request, such as startup and the export command. Only such work bypasses the
+// project limits of the database.
+func ContextWithSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemCtxKey{}, true)
+}
+
+func IsSystem(ctx context.Context) bool {
+	system, _ := ctx.Value(systemCtxKey{}).(bool)
+
+	return system
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "context"
+
+type cacheBypassCtxKey struct{}
+
+// ContextWithCacheBypass makes reads skip the cache. Fresh values are still cached.
+func ContextWithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassCtxKey{}, true)
+}
+
+func CacheBypassFromContext(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cacheBypassCtxKey{}).(bool)
+
+	return bypass
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// Priority scopes: goods are ordered among all goods of the project or among
+// the goods of the same category.
+const (
+	PriorityScopeProject  = "project"
+	PriorityScopeCategory = "category"
+)
+
+// Category is a node of the category tree of a project. Path lists the IDs
+// from the root down to the category, e.g. /1/4/9/.
+type Category struct {
+	ID        int       `json:"id"`
+	ProjectID int       `json:"projectId"`
+	ParentID  *int      `json:"parentId"`
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// IsAncestorOf reports whether other is the category or one of its descendants.
+func (c *Category) IsAncestorOf(other Category) bool {
+	return strings.HasPrefix(other.Path, c.Path)
+}
+
+type CategoryCreateRequest struct {
+	Name     string `json:"name"`
+	ParentID *int   `json:"parentId"`
+}
+
+func (c *CategoryCreateRequest) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return ErrEmptyCategoryName
+	}
+
+	if c.ParentID != nil && *c.ParentID <= 0 {
+		return ErrInvalidIDOrProjectID
+	}
+
+	return nil
+}
+
+type CategoryRenameRequest struct {
+	Name string `json:"name"`
+}
+
+// CategoryMoveRequest moves the category under ParentID, or to the root if it is nil.
+type CategoryMoveRequest struct {
+	ParentID *int `json:"parentId"`
+}
+
+type CategoriesResponse struct {
+	Categories []Category `json:"categories"`
+}
+
+type CategoryDeleteResponse struct {
+	// Deleted is the number of deleted categories, the category and its descendants.
+	Deleted int `json:"deleted"`
+	// MovedGoods is the number of goods moved to the parent of the category.
+	MovedGoods int `json:"movedGoods"`
+}
+
+// GoodCategoryRequest assigns the good to CategoryID, or unassigns it if it is nil.
+type GoodCategoryRequest struct {
+	CategoryID *int `json:"categoryId"`
+}
@@ -3,9 +3,46 @@ package entity
 import "errors"
 
 var (
-	ErrEmptyName            = errors.New("invalid good name")
-	ErrInvalidIDOrProjectID = errors.New("id and projectID must be positive")
-	ErrGoodNotFound         = errors.New("good is not found in the database")
-	ErrNegativePriority     = errors.New("priority must be positive")
-	ErrSamePriority         = errors.New("new priority equals to current priority")
+	ErrEmptyName              = errors.New("invalid good name")
+	ErrInvalidIDOrProjectID   = errors.New("id and projectID must be positive")
+	ErrGoodNotFound           = errors.New("good is not found in the database")
+	ErrNegativePriority       = errors.New("priority must be positive")
+	ErrSamePriority           = errors.New("new priority equals to current priority")
+	ErrWebhookNotFound        = errors.New("webhook is not found in the database")
+	ErrInvalidWebhookURL      = errors.New("webhook url must be an absolute http or https url")
+	ErrWebhookTargetNotPublic = errors.New("webhook url must not point to a private, loopback or link-local address")
+	ErrUnauthorized           = errors.New("missing or invalid credentials")
+	ErrForbidden              = errors.New("access denied")
+	ErrAPIKeyNotFound         = errors.New("api key is not found in the database")
+	ErrInvalidScope           = errors.New("unknown api key scope")
+	ErrEmptyAPIKeyName        = errors.New("api key name must not be empty")
+	ErrEmptyAPIKeyScopes      = errors.New("api key must have at least one scope")
+	ErrEmptyAPIKeyProjects    = errors.New("api key must have at least one project unless it has the admin scope")
+	ErrAPIKeyNameExists       = errors.New("api key name is already used by an active key")
+	ErrRateLimited            = errors.New("rate limit exceeded")
+	ErrInvalidTag             = errors.New("tag must be 1 to 64 letters, digits, '-', '_', '.' or ':'")
+	ErrTooManyTags            = errors.New("good can have at most 32 tags")
+	ErrInvalidTagMode         = errors.New("tag mode must be any or all")
+	ErrTagNotFound            = errors.New("tag is not used by any good of the project")
+	ErrTagExists              = errors.New("tag is already used in the project")
+	ErrCategoryNotFound       = errors.New("category is not found in the database")
+	ErrCategoryExists         = errors.New("category with this name already exists under the parent")
+	ErrCategoryCycle          = errors.New("category can't be moved under itself or its descendants")
+	ErrEmptyCategoryName      = errors.New("category name must not be empty")
+	ErrInvalidAttributeSchema = errors.New("invalid attribute schema")
+	ErrInvalidAttribute       = errors.New("invalid attribute")
+	ErrInvalidAttributeFilter = errors.New("invalid attribute filter")
+	ErrUnknownAttributeType   = errors.New("type must be string, integer, number or boolean")
+	ErrInvalidAttributeEnum   = errors.New("enum is only allowed for string attributes")
+	ErrInvalidAttributeBounds = errors.New("min must not exceed max, and boolean attributes have no bounds")
+	ErrAttributeType          = errors.New("does not match the attribute type")
+	ErrAttributeNotInEnum     = errors.New("is not one of the allowed values")
+	ErrAttributeOutOfBounds   = errors.New("is out of the min and max bounds")
+	ErrInvalidSearchQuery     = errors.New("search query must be 1 to 256 characters")
+	ErrUnknownSearchLanguage  = errors.New("unknown text search configuration")
+	ErrInvalidFormat          = errors.New("format must be csv or jsonl")
+	ErrInvalidImport          = errors.New("invalid import")
+	ErrInvalidExternalID      = errors.New("external id must be up to 128 characters without control characters")
+	ErrExternalIDRequired     = errors.New("external id is required")
+	ErrExternalIDExists       = errors.New("external id is already used in the project")
 )
@@ -0,0 +1,30 @@
+package entity
+
+// Formats of exported and imported goods.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+type ExportRequest struct {
+	Format string
+	// Filter selects the goods like the goods list. Limit and Offset are ignored.
+	Filter ListRequest
+}
+
+// Validate defaults the format to CSV and normalizes the tag filter.
+func (r *ExportRequest) Validate() error {
+	if r.Format == "" {
+		r.Format = FormatCSV
+	}
+
+	if r.Format != FormatCSV && r.Format != FormatJSONL {
+		return ErrInvalidFormat
+	}
+
+	if r.Filter.ProjectID <= 0 {
+		return ErrInvalidIDOrProjectID
+	}
+
+	return r.Filter.ValidateTags()
+}
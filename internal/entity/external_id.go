@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const maxExternalIDLength = 128
+
+// NormalizeExternalID trims the external id. External ids are up to 128
+// characters without control characters.
+func NormalizeExternalID(externalID string) (string, error) {
+	externalID = strings.TrimSpace(externalID)
+
+	if externalID == "" {
+		return "", ErrExternalIDRequired
+	}
+
+	if utf8.RuneCountInString(externalID) > maxExternalIDLength || strings.IndexFunc(externalID, unicode.IsControl) >= 0 {
+		return "", ErrInvalidExternalID
+	}
+
+	return externalID, nil
+}
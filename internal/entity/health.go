@@ -0,0 +1,27 @@
+package entity
+
+// Health statuses.
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+	HealthStatusDown     = "down"
+	HealthStatusDraining = "draining"
+)
+
+type HealthCheck struct {
+	Status    string `json:"status"`
+	Optional  bool   `json:"optional,omitempty"`
+	Error     string `json:"error,omitempty"`
+	LatencyMS int64  `json:"latencyMs"`
+}
+
+type HealthReport struct {
+	Status string                 `json:"status"`
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+// Ready reports whether the service can serve requests, possibly without
+// optional dependencies.
+func (h *HealthReport) Ready() bool {
+	return h.Status == HealthStatusOK || h.Status == HealthStatusDegraded
+}
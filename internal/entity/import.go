@@ -0,0 +1,138 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxImportRows is the number of goods that can be imported at once.
+const MaxImportRows = 10000
+
+// Import modes.
+const (
+	// ImportModeInsert creates a good for every row.
+	ImportModeInsert = "insert"
+	// ImportModeUpsert updates the goods of the rows with an id or a used external id and creates the others.
+	ImportModeUpsert = "upsert"
+)
+
+// Sources of the priorities of imported goods.
+const (
+	// ImportPrioritiesAppend places the goods after the goods of the project in the order of the file.
+	ImportPrioritiesAppend = "append"
+	// ImportPrioritiesFile takes the priorities from the file.
+	ImportPrioritiesFile = "file"
+)
+
+type ImportRequest struct {
+	ProjectID  int
+	Mode       string
+	Format     string
+	Priorities string
+	// DryRun validates and applies the import in a transaction that is rolled back.
+	DryRun bool
+}
+
+// Validate defaults to inserting CSV rows with appended priorities.
+func (r *ImportRequest) Validate() error {
+	if r.ProjectID <= 0 {
+		return ErrInvalidIDOrProjectID
+	}
+
+	if r.Mode == "" {
+		r.Mode = ImportModeInsert
+	}
+
+	if r.Format == "" {
+		r.Format = FormatCSV
+	}
+
+	if r.Priorities == "" {
+		r.Priorities = ImportPrioritiesAppend
+	}
+
+	if r.Mode != ImportModeInsert && r.Mode != ImportModeUpsert {
+		return fmt.Errorf("%w: mode must be insert or upsert", ErrInvalidImport)
+	}
+
+	if r.Format != FormatCSV && r.Format != FormatJSONL {
+		return ErrInvalidFormat
+	}
+
+	if r.Priorities != ImportPrioritiesAppend && r.Priorities != ImportPrioritiesFile {
+		return fmt.Errorf("%w: priorities must be append or file", ErrInvalidImport)
+	}
+
+	return nil
+}
+
+// ImportRow is a good of an import file. The columns of an export can be imported.
+type ImportRow struct {
+	GoodCreateRequest
+
+	// Line is the line of the row in the file.
+	Line int `json:"-"`
+	// ID is the good updated by an upsert. It is ignored by inserts.
+	ID       int `json:"id"`
+	Priority int `json:"priority"`
+}
+
+// Validate checks the good like GoodCreateRequest.Validate and the priority
+// if it is taken from the file.
+func (r *ImportRow) Validate(request ImportRequest) error {
+	if err := r.GoodCreateRequest.Validate(); err != nil {
+		return err
+	}
+
+	if request.Mode == ImportModeInsert {
+		r.ID = 0
+	}
+
+	if request.Priorities == ImportPrioritiesFile && r.Priority <= 0 {
+		return ErrNegativePriority
+	}
+
+	return nil
+}
+
+type ImportError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// UpdatedGood is a good updated by an import with the good as it was before.
+type UpdatedGood struct {
+	Good
+
+	Previous Good
+}
+
+// ImportResult is the outcome of applying the valid rows of an import.
+type ImportResult struct {
+	Created []Good
+	Updated []UpdatedGood
+	// Errors are the rows that can't be applied, such as rows of unknown categories.
+	Errors []ImportError
+}
+
+type ImportResponse struct {
+	DryRun  bool          `json:"dryRun"`
+	Total   int           `json:"total"`
+	Created int           `json:"created"`
+	Updated int           `json:"updated"`
+	Failed  int           `json:"failed"`
+	Errors  []ImportError `json:"errors"`
+}
+
+// ImportEvent summarizes an applied import. The imported goods have their own logs.
+type ImportEvent struct {
+	ProjectID int       `json:"projectId"`
+	Mode      string    `json:"mode"`
+	Total     int       `json:"total"`
+	Created   int       `json:"created"`
+	Updated   int       `json:"updated"`
+	Failed    int       `json:"failed"`
+	Actor     string    `json:"actor"`
+	RequestID string    `json:"requestId"`
+	EventTime time.Time `json:"eventTime"`
+}
@@ -11,48 +11,121 @@ type Project struct {
 }
 
 type Good struct {
-	ID          int       `json:"id"`
-	ProjectID   int       `json:"projectId"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Priority    int       `json:"priority"`
-	Removed     bool      `json:"removed"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID          int            `json:"id"`
+	ProjectID   int            `json:"projectId"`
+	ExternalID  *string        `json:"externalId"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Priority    int            `json:"priority"`
+	Removed     bool           `json:"removed"`
+	Tags        []string       `json:"tags"`
+	CategoryID  *int           `json:"categoryId"`
+	Attributes  map[string]any `json:"attributes"`
+	CreatedAt   time.Time      `json:"createdAt"`
+}
+
+// ChangedGood is a good changed by an operation on many goods, with the
+// values it had before. Only the changed values are set.
+type ChangedGood struct {
+	Good
+
+	PrevTags       []string
+	PrevCategoryID *int
 }
 
 type GoodCreateRequest struct {
-	Name        string  `json:"name"`
-	Description *string `json:"description"`
+	// ExternalID identifies the good in an external system, unique in the project.
+	ExternalID  *string  `json:"externalId"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description"`
+	Tags        []string `json:"tags"`
+	CategoryID  *int     `json:"categoryId"`
+	// Attributes are checked against the attribute schema of the project.
+	Attributes map[string]any `json:"attributes"`
 }
 
+// Validate also normalizes the tags and the external id.
 func (g *GoodCreateRequest) Validate() error {
 	if g.Name == "" {
 		return ErrEmptyName
 	}
 
+	if g.CategoryID != nil && *g.CategoryID <= 0 {
+		return ErrInvalidIDOrProjectID
+	}
+
+	if g.ExternalID != nil {
+		externalID, err := NormalizeExternalID(*g.ExternalID)
+		if err != nil {
+			return err
+		}
+
+		g.ExternalID = &externalID
+	}
+
+	tags, err := NormalizeTags(g.Tags)
+	if err != nil {
+		return err
+	}
+
+	g.Tags = tags
+
+	if g.Attributes == nil {
+		g.Attributes = map[string]any{}
+	}
+
 	return nil
 }
 
 type GoodLog struct {
-	Operation   string    `json:"operation"`
-	GoodID      int       `json:"goodId"`
-	ProjectID   int       `json:"projectId"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Priority    int       `json:"priority"`
-	Removed     bool      `json:"removed"`
-	EventTime   time.Time `json:"evenTime"`
+	ID              uint64         `json:"id"`
+	Operation       string         `json:"operation"`
+	GoodID          int            `json:"goodId"`
+	ProjectID       int            `json:"projectId"`
+	ExternalID      *string        `json:"externalId,omitempty"`
+	Name            string         `json:"name"`
+	Description     string         `json:"description"`
+	Priority        int            `json:"priority"`
+	Removed         bool           `json:"removed"`
+	PrevName        *string        `json:"prevName,omitempty"`
+	PrevDescription *string        `json:"prevDescription,omitempty"`
+	PrevPriority    *int           `json:"prevPriority,omitempty"`
+	Tags            []string       `json:"tags"`
+	PrevTags        []string       `json:"prevTags,omitempty"`
+	CategoryID      *int           `json:"categoryId"`
+	PrevCategoryID  *int           `json:"prevCategoryId,omitempty"`
+	Attributes      map[string]any `json:"attributes"`
+	PrevAttributes  map[string]any `json:"prevAttributes,omitempty"`
+	Actor           string         `json:"actor"`
+	ClientIP        string         `json:"clientIp"`
+	UserAgent       string         `json:"userAgent"`
+	RequestID       string         `json:"requestId"`
+	EventTime       time.Time      `json:"evenTime"`
+}
+
+func (g *GoodLog) IsChange() bool {
+	switch g.Operation {
+	case "create", "update", "delete", "reprioritize":
+		return true
+	default:
+		return false
+	}
 }
 
 type GoodUpdate struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
+	// Tags replace the tags of the good unless nil.
+	Tags *[]string `json:"tags"`
+	// Attributes replace the attributes of the good unless nil.
+	Attributes map[string]any `json:"attributes"`
 }
 
 type GoodDeleteResponse struct {
-	ID         int  `json:"id"`
-	CampaignID int  `json:"campaignId"`
-	Removed    bool `json:"removed"`
+	ID         int     `json:"id"`
+	CampaignID int     `json:"campaignId"`
+	Removed    bool    `json:"removed"`
+	ExternalID *string `json:"-"`
 }
 
 type PriorityRequest struct {
@@ -60,8 +133,10 @@ type PriorityRequest struct {
 }
 
 type Priority struct {
-	ID       int `json:"id"`
-	Priority int `json:"priority"`
+	ID               int     `json:"id"`
+	Priority         int     `json:"priority"`
+	PreviousPriority int     `json:"-"`
+	ExternalID       *string `json:"-"`
 }
 
 type PriorityResponse struct {
@@ -69,8 +144,16 @@ type PriorityResponse struct {
 }
 
 type ListRequest struct {
-	Limit  int
-	Offset int
+	Limit     int
+	Offset    int
+	ProjectID int
+	// Tags filter goods having any or, with TagModeAll, all of them.
+	Tags    []string
+	TagMode string
+	// CategoryID filters goods in the category and its descendants.
+	CategoryID int
+	// Attributes filter goods by the attributes of their project.
+	Attributes []AttributeFilter
 }
 
 func (l *ListRequest) Validate() {
@@ -81,6 +164,30 @@ func (l *ListRequest) Validate() {
 	if l.Offset < 0 {
 		l.Offset = 0
 	}
+
+	if l.ProjectID < 0 {
+		l.ProjectID = 0
+	}
+}
+
+// ValidateTags normalizes the tag filter.
+func (l *ListRequest) ValidateTags() error {
+	if l.TagMode == "" {
+		l.TagMode = TagModeAny
+	}
+
+	if l.TagMode != TagModeAny && l.TagMode != TagModeAll {
+		return ErrInvalidTagMode
+	}
+
+	tags, err := NormalizeTags(l.Tags)
+	if err != nil {
+		return err
+	}
+
+	l.Tags = tags
+
+	return nil
 }
 
 type Meta struct {
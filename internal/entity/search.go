@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const maxSearchQueryLength = 256
+
+type SearchRequest struct {
+	ProjectID int
+	Query     string
+	Limit     int
+	Offset    int
+}
+
+// Validate trims the query and applies the default page.
+func (r *SearchRequest) Validate() error {
+	if r.ProjectID <= 0 {
+		return ErrInvalidIDOrProjectID
+	}
+
+	r.Query = strings.TrimSpace(r.Query)
+	if r.Query == "" || utf8.RuneCountInString(r.Query) > maxSearchQueryLength {
+		return ErrInvalidSearchQuery
+	}
+
+	if r.Limit <= 0 {
+		r.Limit = 10
+	}
+
+	if r.Offset < 0 {
+		r.Offset = 0
+	}
+
+	return nil
+}
+
+// SearchHighlight has the name and description of a found good with the
+// matched words wrapped in <mark> tags. Other HTML is escaped.
+type SearchHighlight struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type SearchResult struct {
+	Good      Good            `json:"good"`
+	Rank      float64         `json:"rank"`
+	Highlight SearchHighlight `json:"highlight"`
+}
+
+type SearchMeta struct {
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+type SearchResponse struct {
+	Meta    SearchMeta     `json:"meta"`
+	Results []SearchResult `json:"results"`
+}
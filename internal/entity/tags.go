@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	maxTagLength   = 64
+	maxTagsPerGood = 32
+)
+
+// Tag filter modes of the goods list.
+const (
+	TagModeAny = "any"
+	TagModeAll = "all"
+)
+
+type Tag struct {
+	Name  string `json:"name"`
+	Goods int    `json:"goods"`
+}
+
+type TagsResponse struct {
+	Tags []Tag `json:"tags"`
+}
+
+type TagRenameRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type TagMergeRequest struct {
+	Sources []string `json:"sources"`
+	Target  string   `json:"target"`
+}
+
+type TagChangeResponse struct {
+	Updated int `json:"updated"`
+}
+
+// NormalizeTag lower-cases and trims the tag. Tags are letters, digits, '-',
+// '_', '.' and ':' up to 64 characters.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+
+	if tag == "" || len(tag) > maxTagLength {
+		return "", ErrInvalidTag
+	}
+
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_.:", r) {
+			return "", ErrInvalidTag
+		}
+	}
+
+	return tag, nil
+}
+
+// NormalizeTags normalizes the tags and returns them sorted and without duplicates.
+// The result is never nil.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag, err := NormalizeTag(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		normalized = append(normalized, tag)
+	}
+
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+
+	if len(normalized) > maxTagsPerGood {
+		return nil, ErrTooManyTags
+	}
+
+	return normalized, nil
+}
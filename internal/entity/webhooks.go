@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, private to providers.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10") //nolint:gochecknoglobals
+
+type Webhook struct {
+	ID           int        `json:"id"`
+	ProjectID    int        `json:"projectId"`
+	URL          string     `json:"url"`
+	Secret       string     `json:"secret,omitempty"`
+	Active       bool       `json:"active"`
+	FailureCount int        `json:"failureCount"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	DisabledAt   *time.Time `json:"disabledAt,omitempty"`
+}
+
+type WebhookCreateRequest struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+func (w *WebhookCreateRequest) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+
+	return nil
+}
+
+// ValidateTarget rejects URLs whose host is localhost or an address that isn't
+// public. Names resolving to such addresses are rejected when events are sent.
+func (w *WebhookCreateRequest) ValidateTarget() error {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return ErrInvalidWebhookURL
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrWebhookTargetNotPublic
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddress(addr) {
+		return ErrWebhookTargetNotPublic
+	}
+
+	return nil
+}
+
+// IsPublicAddress reports whether webhooks may be sent to the address.
+// Loopback, private, link-local, multicast and unspecified addresses are not public.
+func IsPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+type WebhookDelivery struct {
+	ID         int       `json:"id"`
+	WebhookID  int       `json:"webhookId"`
+	Operation  string    `json:"operation"`
+	GoodID     int       `json:"goodId"`
+	Attempt    int       `json:"attempt"`
+	StatusCode *int      `json:"statusCode,omitempty"`
+	Error      *string   `json:"error,omitempty"`
+	Success    bool      `json:"success"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+type WebhooksListResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
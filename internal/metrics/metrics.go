@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "hezzl_goods"
+
+// Cache results.
+const (
+	CacheHit    = "hit"
+	CacheMiss   = "miss"
+	CacheBypass = "bypass"
+)
+
+//nolint:gochecknoglobals
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by chi route pattern, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	CacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Redis cache lookups by key class and result.",
+	}, []string{"cache", "result"})
+
+	NATSPublishFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "nats",
+		Name:      "publish_failures_total",
+		Help:      "Messages that failed to be published to NATS.",
+	}, []string{"subject"})
+
+	NATSBufferedEvents = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "nats",
+		Name:      "buffered_events",
+		Help:      "Events kept in memory until NATS is reachable again.",
+	})
+
+	NATSDroppedEvents = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "nats",
+		Name:      "dropped_events_total",
+		Help:      "Events dropped because the local buffer was full while NATS was down.",
+	})
+
+	WebhookDroppedEvents = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhooks",
+		Name:      "dropped_events_total",
+		Help:      "Events dropped because the webhook delivery queue was full.",
+	})
+
+	ConsumerBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "batch_size",
+		Help:      "Number of goods logs flushed to ClickHouse at once.",
+		Buckets:   prometheus.LinearBuckets(10, 10, 10), //nolint:mnd
+	})
+
+	ConsumerFlushDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "consumer",
+		Name:      "flush_duration_seconds",
+		Help:      "Time to flush a batch of goods logs to ClickHouse.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	ClickHouseInsertErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "clickhouse",
+		Name:      "insert_errors_total",
+		Help:      "Batches of goods logs that failed to be inserted into ClickHouse.",
+	})
+)
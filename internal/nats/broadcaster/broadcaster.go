@@ -0,0 +1,185 @@
+package broadcaster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultHistorySize    = 1000
+	subscriberChannelSize = 64
+)
+
+// Event is a goods change with the ID the broadcaster gave it. IDs grow in the
+// order the broadcaster received the events, so clients can resume with the
+// last ID they have seen. A reset event tells the client that events after its
+// ID are unknown here and it has to reload the goods.
+type Event struct {
+	ID    uint64          `json:"id"`
+	Reset bool            `json:"reset,omitempty"`
+	Log   *entity.GoodLog `json:"log,omitempty"`
+}
+
+type subscriber struct {
+	projectID int
+	events    chan Event
+}
+
+type Broadcaster struct {
+	natsConn *nats.Conn
+	subject  string
+
+	mu          sync.Mutex
+	lastID      uint64
+	history     []Event
+	historySize int
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+// New creates a broadcaster. Its IDs start at a random number, so that IDs given
+// by other instances or before a restart are not mistaken for its own.
+func New(natsConn *nats.Conn, subject string) *Broadcaster {
+	return &Broadcaster{
+		natsConn:    natsConn,
+		subject:     subject,
+		lastID:      rand.Uint64() >> 1, //nolint:gosec
+		historySize: defaultHistorySize,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+func (b *Broadcaster) Start(ctx context.Context) error {
+	subscription, err := b.natsConn.Subscribe(b.subject, func(msg *nats.Msg) {
+		var logMsg entity.GoodLog
+		if err := json.Unmarshal(msg.Data, &logMsg); err != nil {
+			log.Warn().Err(err).Msg("failed to unmarshal message in broadcaster")
+
+			return
+		}
+
+		b.publish(logMsg)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe broadcaster: %w", err)
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		if err := subscription.Unsubscribe(); err != nil {
+			log.Warn().Err(err).Msg("failed to unsubscribe broadcaster")
+		}
+
+		b.close()
+	}()
+
+	return nil
+}
+
+// Subscribe returns the buffered events of the project after lastEventID and a
+// channel with the following ones. When lastEventID is not the latest ID or a
+// kept event, for example because it was given by another instance, a reset
+// event with the latest ID is returned instead. The channel is closed when the
+// broadcaster stops or the subscriber falls behind.
+func (b *Broadcaster) Subscribe(projectID int, lastEventID uint64) ([]Event, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var replay []Event
+
+	if lastEventID != 0 && lastEventID != b.lastID {
+		i := slices.IndexFunc(b.history, func(event Event) bool { return event.ID == lastEventID })
+
+		if i < 0 {
+			replay = []Event{{ID: b.lastID, Reset: true}}
+		} else {
+			for _, event := range b.history[i+1:] {
+				if event.Log.ProjectID == projectID {
+					replay = append(replay, event)
+				}
+			}
+		}
+	}
+
+	sub := &subscriber{
+		projectID: projectID,
+		events:    make(chan Event, subscriberChannelSize),
+	}
+
+	if b.closed {
+		close(sub.events)
+
+		return replay, sub.events, func() {}
+	}
+
+	b.subscribers[sub] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[sub]; ok {
+			delete(b.subscribers, sub)
+			close(sub.events)
+		}
+	}
+
+	return replay, sub.events, unsubscribe
+}
+
+func (b *Broadcaster) publish(logMsg entity.GoodLog) {
+	if !logMsg.IsChange() {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+
+	event := Event{
+		ID:  b.lastID,
+		Log: &logMsg,
+	}
+
+	b.history = append(b.history, event)
+	if len(b.history) > b.historySize {
+		b.history = b.history[len(b.history)-b.historySize:]
+	}
+
+	for sub := range b.subscribers {
+		if sub.projectID != logMsg.ProjectID {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			log.Warn().Int("projectId", sub.projectID).Msg("stream subscriber is too slow, dropping it")
+
+			delete(b.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+func (b *Broadcaster) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+
+	for sub := range b.subscribers {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+}
@@ -3,25 +3,46 @@ package consumer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/metrics"
 	"github.com/romanpitatelev/hezzl-goods/internal/repository/clickhouse"
+	"github.com/romanpitatelev/hezzl-goods/internal/tracing"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	defaultBatchSize = 30
+	defaultBatchSize  = 30
+	drainPollInterval = 10 * time.Millisecond
+	// queueGroup shares the logs between ingest replicas, so every log is written once.
+	queueGroup = "clickhouse"
 )
 
+var errDrainTimeout = errors.New("timed out draining the subscription")
+
 type NATSConsumer struct {
 	clickhouseStore *clickhouse.Store
 	natsConn        *nats.Conn
-	batch           []entity.GoodLog
+	subscription    *nats.Subscription
+	batch           []batchEntry
 	batchSize       int
 	batchMutex      sync.Mutex
+	flushes         sync.WaitGroup
+}
+
+// batchEntry is a log waiting for the flush, with the trace of the change that
+// produced it.
+type batchEntry struct {
+	log         entity.GoodLog
+	spanContext trace.SpanContext
 }
 
 func New(ctx context.Context, natsConn *nats.Conn, clickhouseStore *clickhouse.Store) *NATSConsumer {
@@ -33,7 +54,7 @@ func New(ctx context.Context, natsConn *nats.Conn, clickhouseStore *clickhouse.S
 }
 
 func (nc *NATSConsumer) Start() error {
-	_, err := nc.natsConn.Subscribe("goods.logs", func(msg *nats.Msg) {
+	subscription, err := nc.natsConn.QueueSubscribe("goods.logs", queueGroup, func(msg *nats.Msg) {
 		var logMsg entity.GoodLog
 		if err := json.Unmarshal(msg.Data, &logMsg); err != nil {
 			log.Err(err).Msg("failed to unmarshal message in NATS Subscribe")
@@ -44,15 +65,24 @@ func (nc *NATSConsumer) Start() error {
 		nc.batchMutex.Lock()
 		defer nc.batchMutex.Unlock()
 
-		nc.batch = append(nc.batch, logMsg)
+		msgCtx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(msg.Header))
+
+		nc.batch = append(nc.batch, batchEntry{
+			log:         logMsg,
+			spanContext: trace.SpanContextFromContext(msgCtx),
+		})
 
 		if len(nc.batch) >= defaultBatchSize {
-			batchToFlush := make([]entity.GoodLog, len(nc.batch))
+			batchToFlush := make([]batchEntry, len(nc.batch))
 			copy(batchToFlush, nc.batch)
 			nc.batch = nc.batch[:0]
 
+			nc.flushes.Add(1)
+
 			go func() {
-				if err := nc.flushBatch(batchToFlush); err != nil {
+				defer nc.flushes.Done()
+
+				if err := nc.flush(batchToFlush); err != nil {
 					log.Error().Err(err).Msg("failed to flush batch to ClickHouse")
 				}
 			}()
@@ -62,26 +92,120 @@ func (nc *NATSConsumer) Start() error {
 		return fmt.Errorf("failed to start nats: %w", err)
 	}
 
+	nc.batchMutex.Lock()
+	nc.subscription = subscription
+	nc.batchMutex.Unlock()
+
 	return nil
 }
 
-func (nc *NATSConsumer) flushBatch(batch []entity.GoodLog) error {
-	tx, err := nc.clickhouseStore.DB().Begin()
+// Stop drains the subscription, so the messages already received are
+// processed, and writes the incomplete batch to ClickHouse. It is a no-op if
+// the consumer wasn't started.
+func (nc *NATSConsumer) Stop(ctx context.Context) error {
+	nc.batchMutex.Lock()
+	subscription := nc.subscription
+	nc.batchMutex.Unlock()
+
+	if subscription == nil {
+		return nil
+	}
+
+	if err := subscription.Drain(); err != nil {
+		return fmt.Errorf("failed to drain subscription: %w", err)
+	}
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for subscription.IsValid() {
+		select {
+		case <-ctx.Done():
+			return errDrainTimeout
+		case <-ticker.C:
+		}
+	}
+
+	nc.flushes.Wait()
+
+	nc.batchMutex.Lock()
+	batchToFlush := nc.batch
+	nc.batch = nil
+	nc.batchMutex.Unlock()
+
+	if len(batchToFlush) == 0 {
+		return nil
+	}
+
+	return nc.flush(batchToFlush)
+}
+
+func (nc *NATSConsumer) flush(batch []batchEntry) error {
+	start := time.Now()
+
+	metrics.ConsumerBatchSize.Observe(float64(len(batch)))
+
+	err := nc.flushBatch(batch)
+	if err != nil {
+		metrics.ClickHouseInsertErrors.Inc()
+	}
+
+	metrics.ConsumerFlushDuration.Observe(time.Since(start).Seconds())
+
+	return err
+}
+
+// flushBatch inserts the batch in one transaction. The flush span links to the
+// traces of the changes, and every insert joins the trace of its change.
+func (nc *NATSConsumer) flushBatch(batch []batchEntry) (err error) {
+	links := make([]trace.Link, 0, len(batch))
+
+	for _, entry := range batch {
+		if entry.spanContext.IsValid() {
+			links = append(links, trace.Link{SpanContext: entry.spanContext})
+		}
+	}
+
+	ctx, span := tracing.Start(context.Background(), "goods_logs flush",
+		trace.WithNewRoot(),
+		trace.WithLinks(links...),
+		tracing.DBAttributes("clickhouse", "INSERT"),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	tx, err := nc.clickhouseStore.DB().BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	stmt, err := tx.Prepare(`
+	stmt, err := tx.PrepareContext(ctx, `
         INSERT INTO goods_logs (
+            log_id,
             id, 
             project_id, 
+            external_id,
             name, 
             description, 
             priority, 
             removed, 
+            tags,
+            category_id,
+            attributes,
             operation, 
+            prev_name,
+            prev_description,
+            prev_priority,
+            prev_tags,
+            prev_category_id,
+            prev_attributes,
+            actor,
+            client_ip,
+            user_agent,
+            request_id,
             event_time
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
     `)
 	if err != nil {
 		return fmt.Errorf("failed to prepare statement: %w", err)
@@ -93,17 +217,48 @@ func (nc *NATSConsumer) flushBatch(batch []entity.GoodLog) error {
 		}
 	}()
 
-	for _, logEntry := range batch {
-		_, err = stmt.Exec(
+	for _, entry := range batch {
+		logEntry := entry.log
+
+		parentCtx := ctx
+		if entry.spanContext.IsValid() {
+			parentCtx = trace.ContextWithRemoteSpanContext(ctx, entry.spanContext)
+		}
+
+		insertCtx, insertSpan := tracing.Start(parentCtx, "clickhouse INSERT goods_logs",
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithLinks(trace.Link{SpanContext: span.SpanContext()}),
+			tracing.DBAttributes("clickhouse", "INSERT"),
+		)
+
+		_, err = stmt.ExecContext(insertCtx,
+			logEntry.ID,
 			logEntry.GoodID,
 			logEntry.ProjectID,
+			logEntry.ExternalID,
 			logEntry.Name,
 			logEntry.Description,
 			logEntry.Priority,
 			logEntry.Removed,
+			nonNil(logEntry.Tags),
+			logEntry.CategoryID,
+			jsonObject(logEntry.Attributes),
 			logEntry.Operation,
+			logEntry.PrevName,
+			logEntry.PrevDescription,
+			logEntry.PrevPriority,
+			nonNil(logEntry.PrevTags),
+			logEntry.PrevCategoryID,
+			jsonObject(logEntry.PrevAttributes),
+			logEntry.Actor,
+			logEntry.ClientIP,
+			logEntry.UserAgent,
+			logEntry.RequestID,
 			logEntry.EventTime,
 		)
+
+		tracing.End(insertSpan, err)
+
 		if err != nil {
 			if rollbackErr := tx.Rollback(); rollbackErr != nil {
 				return fmt.Errorf("exec error: %w, rollback error: %w", err, rollbackErr)
@@ -121,3 +276,28 @@ func (nc *NATSConsumer) flushBatch(batch []entity.GoodLog) error {
 
 	return nil
 }
+
+// nonNil returns an empty slice for nil, as ClickHouse arrays can't be NULL.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+// jsonObject encodes the values for a String column, empty for nil.
+func jsonObject(values map[string]any) string {
+	if values == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to marshal attributes")
+
+		return ""
+	}
+
+	return string(data)
+}
@@ -3,31 +3,180 @@ package producer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/nats-io/nats.go"
+	"github.com/romanpitatelev/hezzl-goods/internal/metrics"
+	"github.com/romanpitatelev/hezzl-goods/internal/tracing"
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const (
+	defaultBufferSize = 10000
+	flushInterval     = time.Second
+)
+
+var errNotConnected = errors.New("nats is not connected")
+
+// NatsWrapper keeps the messages published while NATS is unreachable in
+// memory and sends them in order once the connection is back. When the buffer
+// is full the oldest messages are dropped.
 type NatsWrapper struct {
-	conn    *nats.Conn
-	subject string
+	conn       *nats.Conn
+	subject    string
+	bufferSize int
+
+	mu     sync.Mutex
+	buffer []*nats.Msg
 }
 
+// New creates the producer and flushes its buffer in the background until ctx is done.
 func New(ctx context.Context, conn *nats.Conn, subject string) *NatsWrapper {
-	return &NatsWrapper{
-		conn:    conn,
-		subject: subject,
+	n := &NatsWrapper{
+		conn:       conn,
+		subject:    subject,
+		bufferSize: defaultBufferSize,
 	}
+
+	go n.flushLoop(ctx)
+
+	return n
 }
 
-func (n *NatsWrapper) Publish(subject string, data interface{}) error {
+// Publish sends data as JSON with the trace context of ctx in the message headers.
+func (n *NatsWrapper) Publish(ctx context.Context, subject string, data interface{}) (err error) {
+	ctx, span := tracing.Start(ctx, subject+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKey.String("nats"),
+			semconv.MessagingDestinationName(subject),
+		),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal NATS message: %w", err)
 	}
 
-	if err = n.conn.Publish(subject, jsonData); err != nil {
-		return fmt.Errorf("failed to publish NATS message: %w", err)
+	msg := &nats.Msg{
+		Subject: subject,
+		Data:    jsonData,
+		Header:  nats.Header{},
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(msg.Header))
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	// Earlier messages are still waiting, so this one has to wait too.
+	if len(n.buffer) > 0 || !n.conn.IsConnected() {
+		n.enqueue(msg)
+
+		return nil
+	}
+
+	if err := n.conn.PublishMsg(msg); err != nil {
+		metrics.NATSPublishFailures.WithLabelValues(subject).Inc()
+		log.Warn().Err(err).Msg("failed to publish NATS message, buffering it")
+
+		n.enqueue(msg)
+	}
+
+	return nil
+}
+
+// Buffered returns the number of messages waiting for NATS.
+func (n *NatsWrapper) Buffered() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return len(n.buffer)
+}
+
+func (n *NatsWrapper) enqueue(msg *nats.Msg) {
+	if len(n.buffer) >= n.bufferSize {
+		n.buffer[0] = nil
+		n.buffer = n.buffer[1:]
+
+		metrics.NATSDroppedEvents.Inc()
+		log.Warn().Msg("NATS buffer is full, dropping the oldest message")
+	}
+
+	n.buffer = append(n.buffer, msg)
+	metrics.NATSBufferedEvents.Set(float64(len(n.buffer)))
+}
+
+func (n *NatsWrapper) flushLoop(ctx context.Context) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n.flush()
+		}
+	}
+}
+
+func (n *NatsWrapper) flush() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if len(n.buffer) == 0 || !n.conn.IsConnected() {
+		return
+	}
+
+	sent := 0
+
+	for _, msg := range n.buffer {
+		if err := n.conn.PublishMsg(msg); err != nil {
+			metrics.NATSPublishFailures.WithLabelValues(msg.Subject).Inc()
+			log.Warn().Err(err).Msg("failed to publish buffered NATS message")
+
+			break
+		}
+
+		sent++
+	}
+
+	n.buffer = append(n.buffer[:0], n.buffer[sent:]...)
+	metrics.NATSBufferedEvents.Set(float64(len(n.buffer)))
+
+	if sent > 0 {
+		log.Info().Msgf("published %d buffered NATS messages", sent)
+	}
+}
+
+// Close publishes the buffered messages one last time. It fails if some of
+// them couldn't be sent and are lost.
+func (n *NatsWrapper) Close() error {
+	n.flush()
+
+	if buffered := n.Buffered(); buffered > 0 {
+		return fmt.Errorf("%w: %d buffered messages are lost", errNotConnected, buffered)
+	}
+
+	return nil
+}
+
+// Ping reports whether the connection is up. NATS has no request to ping the
+// server, so the status of the connection is used.
+func (n *NatsWrapper) Ping(_ context.Context) error {
+	if status := n.conn.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("%w: %s", errNotConnected, status)
 	}
 
 	return nil
@@ -0,0 +1,156 @@
+package apikeysrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+)
+
+const (
+	uniqueViolation = "23505"
+	// nameIndex keeps the names of active keys unique.
+	nameIndex = "idx_api_keys_name"
+)
+
+type Repo struct {
+	db *postgres.DataStore
+}
+
+func New(db *postgres.DataStore) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func (r *Repo) CreateAPIKey(ctx context.Context, req entity.APIKeyCreateRequest, prefix, hash string) (entity.APIKey, error) {
+	query := `
+INSERT INTO api_keys (name, key_prefix, key_hash, project_ids, scopes)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, key_prefix, project_ids, scopes, created_at, rotated_at, revoked_at
+`
+	row := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, req.Name, prefix, hash, req.ProjectIDs, req.Scopes)
+
+	apiKey, err := scanAPIKey(row)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == nameIndex {
+			return entity.APIKey{}, entity.ErrAPIKeyNameExists
+		}
+
+		return entity.APIKey{}, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+func (r *Repo) GetAPIKeyByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	query := `
+SELECT id, name, key_prefix, project_ids, scopes, created_at, rotated_at, revoked_at
+FROM api_keys
+WHERE key_hash = $1 AND revoked_at IS NULL
+`
+	row := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, hash)
+
+	apiKey, err := scanAPIKey(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.APIKey{}, entity.ErrAPIKeyNotFound
+		}
+
+		return entity.APIKey{}, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+func (r *Repo) GetAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, `
+SELECT id, name, key_prefix, project_ids, scopes, created_at, rotated_at, revoked_at
+FROM api_keys
+ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("error while querying in GetAPIKeys(): %w", err)
+	}
+
+	defer rows.Close()
+
+	apiKeys := make([]entity.APIKey, 0)
+
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning api key: %w", err)
+		}
+
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating api keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+func (r *Repo) RotateAPIKey(ctx context.Context, id int, prefix, hash string) (entity.APIKey, error) {
+	query := `
+UPDATE api_keys
+SET key_prefix = $2,
+	key_hash = $3,
+	rotated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND revoked_at IS NULL
+RETURNING id, name, key_prefix, project_ids, scopes, created_at, rotated_at, revoked_at
+`
+	row := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, id, prefix, hash)
+
+	apiKey, err := scanAPIKey(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.APIKey{}, entity.ErrAPIKeyNotFound
+		}
+
+		return entity.APIKey{}, fmt.Errorf("failed to rotate api key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+func (r *Repo) RevokeAPIKey(ctx context.Context, id int) error {
+	cmdTag, err := r.db.GetTXFromContext(ctx).Exec(ctx,
+		`UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND revoked_at IS NULL`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (entity.APIKey, error) {
+	var apiKey entity.APIKey
+
+	if err := row.Scan(
+		&apiKey.ID,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.ProjectIDs,
+		&apiKey.Scopes,
+		&apiKey.CreatedAt,
+		&apiKey.RotatedAt,
+		&apiKey.RevokedAt,
+	); err != nil {
+		return entity.APIKey{}, fmt.Errorf("failed to scan api key: %w", err)
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1 @@
+ALTER TABLE goods_logs DROP COLUMN IF EXISTS actor
@@ -0,0 +1 @@
+ALTER TABLE goods_logs ADD COLUMN IF NOT EXISTS actor String DEFAULT '' AFTER operation
@@ -0,0 +1,7 @@
+ALTER TABLE goods_logs
+    DROP COLUMN IF EXISTS request_id,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS client_ip,
+    DROP COLUMN IF EXISTS prev_priority,
+    DROP COLUMN IF EXISTS prev_description,
+    DROP COLUMN IF EXISTS prev_name
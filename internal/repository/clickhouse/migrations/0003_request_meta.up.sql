@@ -0,0 +1,7 @@
+ALTER TABLE goods_logs
+    ADD COLUMN IF NOT EXISTS prev_name Nullable(String) AFTER removed,
+    ADD COLUMN IF NOT EXISTS prev_description Nullable(String) AFTER prev_name,
+    ADD COLUMN IF NOT EXISTS prev_priority Nullable(UInt32) AFTER prev_description,
+    ADD COLUMN IF NOT EXISTS client_ip String DEFAULT '' AFTER actor,
+    ADD COLUMN IF NOT EXISTS user_agent String DEFAULT '' AFTER client_ip,
+    ADD COLUMN IF NOT EXISTS request_id String DEFAULT '' AFTER user_agent
@@ -0,0 +1,3 @@
+ALTER TABLE goods_logs
+    DROP COLUMN IF EXISTS prev_tags,
+    DROP COLUMN IF EXISTS tags
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE goods_logs
+    ADD COLUMN IF NOT EXISTS tags Array(String) DEFAULT [] AFTER removed,
+    ADD COLUMN IF NOT EXISTS prev_tags Array(String) DEFAULT [] AFTER prev_priority
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE goods_logs
+    DROP COLUMN IF EXISTS prev_category_id,
+    DROP COLUMN IF EXISTS category_id
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE goods_logs
+    ADD COLUMN IF NOT EXISTS category_id Nullable(UInt32) AFTER tags,
+    ADD COLUMN IF NOT EXISTS prev_category_id Nullable(UInt32) AFTER prev_tags
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE goods_logs
+    DROP COLUMN IF EXISTS prev_attributes,
+    DROP COLUMN IF EXISTS attributes
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE goods_logs
+    ADD COLUMN IF NOT EXISTS attributes String DEFAULT '{}' AFTER category_id,
+    ADD COLUMN IF NOT EXISTS prev_attributes String DEFAULT '' AFTER prev_category_id
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE goods_logs
+    DROP COLUMN IF EXISTS external_id
\ No newline at end of file
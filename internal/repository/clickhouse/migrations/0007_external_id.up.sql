@@ -0,0 +1,2 @@
+ALTER TABLE goods_logs
+    ADD COLUMN IF NOT EXISTS external_id Nullable(String) AFTER project_id
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE goods_logs
+    ADD COLUMN IF NOT EXISTS log_id UInt64 FIRST
\ No newline at end of file
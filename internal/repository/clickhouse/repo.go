@@ -26,23 +26,41 @@ type Config struct {
 	Dsn string
 }
 
-func New(ctx context.Context, cfg Config) (*Store, error) {
+// Open creates the store without waiting for ClickHouse to be reachable.
+func Open(cfg Config) (*Store, error) {
 	opt, err := clickhouse.ParseDSN(cfg.Dsn)
 	if err != nil {
 		return nil, fmt.Errorf("invalid ClickHouse DSN: %w", err)
 	}
 
-	db := clickhouse.OpenDB(opt)
-	if err := db.PingContext(ctx); err != nil {
-		return nil, fmt.Errorf("failed to ping ClickHouse: %w", err)
-	}
-
 	return &Store{
-		db:  db,
+		db:  clickhouse.OpenDB(opt),
 		dsn: cfg.Dsn,
 	}, nil
 }
 
+// New opens the store and fails if ClickHouse is unreachable.
+func New(ctx context.Context, cfg Config) (*Store, error) {
+	store, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := store.Ping(ctx); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+func (c *Store) Ping(ctx context.Context) error {
+	if err := c.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping ClickHouse: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Store) Migrate(direction migrate.MigrationDirection) error {
 	suffix := "up.sql"
 	if direction == migrate.Down {
@@ -55,6 +73,10 @@ func (c *Store) Migrate(direction migrate.MigrationDirection) error {
 	}
 
 	sort.Slice(entries, func(i, j int) bool {
+		if direction == migrate.Down {
+			return entries[i].Name() > entries[j].Name()
+		}
+
 		return entries[i].Name() < entries[j].Name()
 	})
 
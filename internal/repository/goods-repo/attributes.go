@@ -0,0 +1,63 @@
+package goodsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+)
+
+// GetAttributeSchema returns the attribute schema of the project. A project
+// without a schema has no attributes.
+func (r *Repo) GetAttributeSchema(ctx context.Context, projectID int) (entity.AttributeSchema, error) {
+	schema := entity.AttributeSchema{
+		ProjectID:  projectID,
+		Attributes: []entity.AttributeDefinition{},
+	}
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		row := tx.QueryRow(ctx, `
+SELECT attributes, updated_at
+FROM attribute_schemas
+WHERE project_id = $1`,
+			projectID)
+
+		return row.Scan(&schema.Attributes, &schema.UpdatedAt)
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return schema, nil
+		}
+
+		return entity.AttributeSchema{}, fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+
+	return schema, nil
+}
+
+// SetAttributeSchema replaces the attribute schema of the project.
+func (r *Repo) SetAttributeSchema(
+	ctx context.Context, projectID int, attributes []entity.AttributeDefinition,
+) (entity.AttributeSchema, error) {
+	schema := entity.AttributeSchema{ProjectID: projectID}
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		row := tx.QueryRow(ctx, `
+INSERT INTO attribute_schemas (project_id, attributes)
+VALUES ($1, $2)
+ON CONFLICT (project_id) DO UPDATE
+SET attributes = EXCLUDED.attributes, updated_at = CURRENT_TIMESTAMP
+RETURNING attributes, updated_at`,
+			projectID, attributes)
+
+		return row.Scan(&schema.Attributes, &schema.UpdatedAt)
+	})
+	if err != nil {
+		return entity.AttributeSchema{}, fmt.Errorf("failed to set attribute schema: %w", err)
+	}
+
+	return schema, nil
+}
@@ -0,0 +1,385 @@
+package goodsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+)
+
+const uniqueViolation = "23505"
+
+func (r *Repo) CreateCategory(ctx context.Context, projectID int, req entity.CategoryCreateRequest) (entity.Category, error) {
+	var category entity.Category
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		parentPath := "/"
+
+		if req.ParentID != nil {
+			parent, err := getCategory(ctx, tx, *req.ParentID, projectID)
+			if err != nil {
+				return err
+			}
+
+			parentPath = parent.Path
+		}
+
+		var id int
+
+		row := tx.QueryRow(ctx, `INSERT INTO categories (project_id, parent_id, name) VALUES ($1, $2, $3) RETURNING id`,
+			projectID, req.ParentID, req.Name)
+		if err := row.Scan(&id); err != nil {
+			return categoryError(err)
+		}
+
+		row = tx.QueryRow(ctx, `
+UPDATE categories SET path = $2
+WHERE id = $1
+RETURNING id, project_id, parent_id, name, path, created_at`,
+			id, parentPath+strconv.Itoa(id)+"/")
+
+		return scanCategory(row, &category)
+	})
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return category, nil
+}
+
+// GetCategories returns the category tree of the project, each category after its parent.
+func (r *Repo) GetCategories(ctx context.Context, projectID int) ([]entity.Category, error) {
+	categories := make([]entity.Category, 0)
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		rows, err := tx.Query(ctx, `
+SELECT id, project_id, parent_id, name, path, created_at
+FROM categories
+WHERE project_id = $1
+ORDER BY path`,
+			projectID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to query categories: %w", err)
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var category entity.Category
+			if err := scanCategory(rows, &category); err != nil {
+				return err
+			}
+
+			categories = append(categories, category)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to read categories: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *Repo) RenameCategory(ctx context.Context, id int, projectID int, name string) (entity.Category, error) {
+	var category entity.Category
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		row := tx.QueryRow(ctx, `
+UPDATE categories SET name = $3
+WHERE id = $1 AND project_id = $2
+RETURNING id, project_id, parent_id, name, path, created_at`,
+			id, projectID, name)
+
+		return scanCategory(row, &category)
+	})
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to rename category: %w", err)
+	}
+
+	return category, nil
+}
+
+// MoveCategory moves the category with its subtree under the parent, or to the root if parentID is nil.
+func (r *Repo) MoveCategory(ctx context.Context, id int, projectID int, parentID *int) (entity.Category, error) {
+	var category entity.Category
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		current, err := getCategory(ctx, tx, id, projectID)
+		if err != nil {
+			return err
+		}
+
+		parentPath := "/"
+
+		if parentID != nil {
+			parent, err := getCategory(ctx, tx, *parentID, projectID)
+			if err != nil {
+				return err
+			}
+
+			if current.IsAncestorOf(parent) {
+				return entity.ErrCategoryCycle
+			}
+
+			parentPath = parent.Path
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE categories SET parent_id = $2 WHERE id = $1`, id, parentID); err != nil {
+			return categoryError(err)
+		}
+
+		if _, err := tx.Exec(ctx, `
+UPDATE categories SET path = $3 || substr(path, length($2) + 1)
+WHERE project_id = $1 AND path LIKE $2 || '%'`,
+			projectID, current.Path, parentPath+strconv.Itoa(id)+"/"); err != nil {
+			return fmt.Errorf("failed to update paths: %w", err)
+		}
+
+		category, err = getCategory(ctx, tx, id, projectID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to move category: %w", err)
+	}
+
+	return category, nil
+}
+
+// DeleteCategory deletes the category with its subtree and moves their goods
+// to the parent of the category. It returns the number of deleted categories
+// and the moved goods.
+func (r *Repo) DeleteCategory(ctx context.Context, id int, projectID int) (int, []entity.ChangedGood, error) {
+	var (
+		deleted int
+		goods   []entity.ChangedGood
+	)
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		category, err := getCategory(ctx, tx, id, projectID)
+		if err != nil {
+			return err
+		}
+
+		goods, err = r.moveGoods(ctx, tx, projectID, category)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `DELETE FROM categories WHERE project_id = $1 AND path LIKE $2 || '%'`,
+			projectID, category.Path)
+		if err != nil {
+			return fmt.Errorf("failed to delete categories: %w", err)
+		}
+
+		deleted = int(tag.RowsAffected())
+
+		return nil
+	})
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return deleted, goods, nil
+}
+
+// moveGoods moves the goods of the category subtree to the parent of the category.
+func (r *Repo) moveGoods(
+	ctx context.Context, tx postgres.Transaction, projectID int, category entity.Category,
+) ([]entity.ChangedGood, error) {
+	rows, err := tx.Query(ctx, `
+WITH previous AS (
+	SELECT id, category_id
+	FROM goods
+	WHERE category_id IN (SELECT id FROM categories WHERE project_id = $1 AND path LIKE $2 || '%')
+	FOR UPDATE
+)
+UPDATE goods
+SET category_id = $3
+FROM previous
+WHERE goods.id = previous.id
+RETURNING goods.id, goods.project_id, goods.name, COALESCE(goods.description, ''), goods.priority,
+	goods.removed, goods.tags, goods.category_id, goods.attributes, goods.external_id, goods.created_at, previous.category_id`,
+		projectID, category.Path, category.ParentID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move goods: %w", err)
+	}
+
+	defer rows.Close()
+
+	var goods []entity.ChangedGood
+
+	for rows.Next() {
+		var good entity.ChangedGood
+		if err := rows.Scan(
+			&good.ID,
+			&good.ProjectID,
+			&good.Name,
+			&good.Description,
+			&good.Priority,
+			&good.Removed,
+			&good.Tags,
+			&good.CategoryID,
+			&good.Attributes,
+			&good.ExternalID,
+			&good.CreatedAt,
+			&good.PrevCategoryID,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan moved good: %w", err)
+		}
+
+		goods = append(goods, good)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read moved goods: %w", err)
+	}
+
+	return goods, nil
+}
+
+// SetGoodCategory assigns the good to the category, or unassigns it if
+// categoryID is nil. With the category priority scope the good is put last in
+// the category. Returns the good and the good as it was before.
+func (r *Repo) SetGoodCategory(ctx context.Context, id int, projectID int, categoryID *int) (entity.Good, entity.Good, error) {
+	var good, previous entity.Good
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		row := tx.QueryRow(ctx, `
+SELECT id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at
+FROM goods WHERE id = $1 AND project_id = $2 FOR UPDATE`,
+			id, projectID)
+
+		if err := row.Scan(
+			&previous.ID,
+			&previous.ProjectID,
+			&previous.Name,
+			&previous.Description,
+			&previous.Priority,
+			&previous.Removed,
+			&previous.Tags,
+			&previous.CategoryID,
+			&previous.Attributes,
+			&previous.ExternalID,
+			&previous.CreatedAt,
+		); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return entity.ErrGoodNotFound
+			}
+
+			return fmt.Errorf("failed to get good: %w", err)
+		}
+
+		if err := checkCategory(ctx, tx, categoryID, projectID); err != nil {
+			return err
+		}
+
+		priority := previous.Priority
+
+		if r.cfg.PriorityScope == entity.PriorityScopeCategory {
+			var err error
+
+			priority, err = r.nextPriority(ctx, tx, projectID, categoryID)
+			if err != nil {
+				return err
+			}
+		}
+
+		row = tx.QueryRow(ctx, `
+UPDATE goods SET category_id = $3, priority = $4
+WHERE id = $1 AND project_id = $2
+RETURNING id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at`,
+			id, projectID, categoryID, priority)
+
+		if err := row.Scan(
+			&good.ID,
+			&good.ProjectID,
+			&good.Name,
+			&good.Description,
+			&good.Priority,
+			&good.Removed,
+			&good.Tags,
+			&good.CategoryID,
+			&good.Attributes,
+			&good.ExternalID,
+			&good.CreatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to scan good: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Good{}, entity.Good{}, fmt.Errorf("failed to set category of good: %w", err)
+	}
+
+	return good, previous, nil
+}
+
+// checkCategory fails if the category is set and doesn't belong to the project.
+func checkCategory(ctx context.Context, tx postgres.Transaction, categoryID *int, projectID int) error {
+	if categoryID == nil {
+		return nil
+	}
+
+	_, err := getCategory(ctx, tx, *categoryID, projectID)
+
+	return err
+}
+
+func getCategory(ctx context.Context, tx postgres.Transaction, id int, projectID int) (entity.Category, error) {
+	var category entity.Category
+
+	row := tx.QueryRow(ctx, `
+SELECT id, project_id, parent_id, name, path, created_at
+FROM categories
+WHERE id = $1 AND project_id = $2`,
+		id, projectID)
+
+	if err := scanCategory(row, &category); err != nil {
+		return entity.Category{}, err
+	}
+
+	return category, nil
+}
+
+func scanCategory(row pgx.Row, category *entity.Category) error {
+	if err := row.Scan(
+		&category.ID,
+		&category.ProjectID,
+		&category.ParentID,
+		&category.Name,
+		&category.Path,
+		&category.CreatedAt,
+	); err != nil {
+		return categoryError(err)
+	}
+
+	return nil
+}
+
+func categoryError(err error) error {
+	var pgErr *pgconn.PgError
+
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return entity.ErrCategoryNotFound
+	case errors.As(err, &pgErr) && pgErr.Code == uniqueViolation:
+		return entity.ErrCategoryExists
+	default:
+		return fmt.Errorf("failed to scan category: %w", err)
+	}
+}
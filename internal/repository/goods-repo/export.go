@@ -0,0 +1,90 @@
+package goodsrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+)
+
+const exportBatchSize = 500
+
+// ExportGoods calls fn for every good matching the filter of the request in
+// priority order. Goods are fetched from a cursor in batches, so that the
+// export doesn't hold all of them in memory. Limit and Offset are ignored.
+func (r *Repo) ExportGoods(ctx context.Context, request entity.ListRequest, fn func(entity.Good) error) error {
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		filter, args := listFilter(request)
+
+		if _, err := tx.Exec(ctx, `
+DECLARE goods_export NO SCROLL CURSOR FOR
+SELECT id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at
+FROM goods
+WHERE `+filter+`
+ORDER BY priority, id`,
+			args...,
+		); err != nil {
+			return fmt.Errorf("failed to declare cursor: %w", err)
+		}
+
+		for {
+			fetched, err := fetchGoods(ctx, tx, fn)
+			if err != nil {
+				return err
+			}
+
+			if fetched < exportBatchSize {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export goods: %w", err)
+	}
+
+	return nil
+}
+
+// fetchGoods calls fn for the next batch of goods of the export cursor and returns their number.
+func fetchGoods(ctx context.Context, tx postgres.Transaction, fn func(entity.Good) error) (int, error) {
+	rows, err := tx.Query(ctx, fmt.Sprintf(`FETCH %d FROM goods_export`, exportBatchSize))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch goods: %w", err)
+	}
+
+	defer rows.Close()
+
+	fetched := 0
+
+	for rows.Next() {
+		var good entity.Good
+		if err := rows.Scan(
+			&good.ID,
+			&good.ProjectID,
+			&good.Name,
+			&good.Description,
+			&good.Priority,
+			&good.Removed,
+			&good.Tags,
+			&good.CategoryID,
+			&good.Attributes,
+			&good.ExternalID,
+			&good.CreatedAt,
+		); err != nil {
+			return 0, fmt.Errorf("failed to scan good: %w", err)
+		}
+
+		if err := fn(good); err != nil {
+			return 0, err
+		}
+
+		fetched++
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read goods: %w", err)
+	}
+
+	return fetched, nil
+}
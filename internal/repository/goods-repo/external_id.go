@@ -0,0 +1,117 @@
+package goodsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+)
+
+const externalIDConstraint = "goods_project_external_id_key"
+
+func (r *Repo) GetGoodByExternalID(ctx context.Context, externalID string, projectID int) (entity.Good, error) {
+	var goods []entity.Good
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) (err error) {
+		goods, err = queryGoods(ctx, tx, `
+SELECT id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at
+FROM goods
+WHERE external_id = $1 AND project_id = $2`,
+			externalID, projectID)
+
+		return err
+	})
+	if err != nil {
+		return entity.Good{}, err
+	}
+
+	if len(goods) == 0 {
+		return entity.Good{}, entity.ErrGoodNotFound
+	}
+
+	return goods[0], nil
+}
+
+// UpsertGood creates the good of the external id of the request or updates
+// the good that has it. Returns the good, the good as it was before an update
+// and whether it was created. Updates keep the priority of the good.
+func (r *Repo) UpsertGood(ctx context.Context, projectID int, req entity.GoodCreateRequest) (entity.Good, entity.Good, bool, error) {
+	var (
+		good, previous entity.Good
+		created        bool
+	)
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		if err := checkCategory(ctx, tx, req.CategoryID, projectID); err != nil {
+			return err
+		}
+
+		goods, err := queryGoods(ctx, tx, `
+SELECT id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at
+FROM goods
+WHERE external_id = $1 AND project_id = $2
+FOR UPDATE`,
+			req.ExternalID, projectID)
+		if err != nil {
+			return err
+		}
+
+		if len(goods) > 0 {
+			previous = goods[0]
+		}
+
+		priority, err := r.nextPriority(ctx, tx, projectID, req.CategoryID)
+		if err != nil {
+			return err
+		}
+
+		// A good created concurrently after the check is updated by the
+		// conflict, so the insert can't fail on the external id.
+		row := tx.QueryRow(ctx, `
+INSERT INTO goods (project_id, name, description, priority, tags, category_id, attributes, external_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (project_id, external_id) DO UPDATE
+SET name = EXCLUDED.name, description = EXCLUDED.description, tags = EXCLUDED.tags,
+	category_id = EXCLUDED.category_id, attributes = EXCLUDED.attributes
+RETURNING id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at,
+	xmax = 0`,
+			projectID, req.Name, req.Description, priority, req.Tags, req.CategoryID, req.Attributes, req.ExternalID)
+
+		if err := row.Scan(
+			&good.ID,
+			&good.ProjectID,
+			&good.Name,
+			&good.Description,
+			&good.Priority,
+			&good.Removed,
+			&good.Tags,
+			&good.CategoryID,
+			&good.Attributes,
+			&good.ExternalID,
+			&good.CreatedAt,
+			&created,
+		); err != nil {
+			return fmt.Errorf("failed to scan good: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Good{}, entity.Good{}, false, fmt.Errorf("failed to upsert good: %w", err)
+	}
+
+	return good, previous, created, nil
+}
+
+// externalIDError reports the violation of the unique external id of the project.
+func externalIDError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == externalIDConstraint {
+		return entity.ErrExternalIDExists
+	}
+
+	return fmt.Errorf("failed to scan good: %w", err)
+}
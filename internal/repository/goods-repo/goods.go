@@ -4,19 +4,27 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/romanpitatelev/hezzl-goods/internal/entity"
 	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
 )
 
+type Config struct {
+	// PriorityScope orders goods among the goods of the project or of the same category.
+	PriorityScope string
+}
+
 type Repo struct {
-	db *postgres.DataStore
+	cfg Config
+	db  *postgres.DataStore
 }
 
-func New(db *postgres.DataStore) *Repo {
+func New(cfg Config, db *postgres.DataStore) *Repo {
 	return &Repo{
-		db: db,
+		cfg: cfg,
+		db:  db,
 	}
 }
 
@@ -27,30 +35,41 @@ func (r *Repo) CreateGood(ctx context.Context, projectID int, req entity.GoodCre
 	)
 
 	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
-		row := tx.QueryRow(ctx, `SELECT COALESCE(MAX(priority), 0) + 1 FROM goods`)
-		if err := row.Scan(&priority); err != nil {
-			return fmt.Errorf("failed to get max priority: %w", err)
+		if err := checkCategory(ctx, tx, req.CategoryID, projectID); err != nil {
+			return err
+		}
+
+		var err error
+
+		priority, err = r.nextPriority(ctx, tx, projectID, req.CategoryID)
+		if err != nil {
+			return err
 		}
 
 		query := `
-INSERT INTO goods (project_id, name, description, priority)
-VALUES ($1, $2, $3, $4)
-RETURNING id, project_id, name, COALESCE(description, ''), priority, removed, created_at	
+INSERT INTO goods (project_id, name, description, priority, tags, category_id, attributes, external_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at	
 `
 
-		goodRow := tx.QueryRow(ctx, query, projectID, req.Name, req.Description, priority)
+		goodRow := tx.QueryRow(ctx, query,
+			projectID, req.Name, req.Description, priority, req.Tags, req.CategoryID, req.Attributes, req.ExternalID)
 
-		err := goodRow.Scan(
+		err = goodRow.Scan(
 			&good.ID,
 			&good.ProjectID,
 			&good.Name,
 			&good.Description,
 			&good.Priority,
 			&good.Removed,
+			&good.Tags,
+			&good.CategoryID,
+			&good.Attributes,
+			&good.ExternalID,
 			&good.CreatedAt,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to scan good: %w", err)
+			return externalIDError(err)
 		}
 
 		return nil
@@ -66,22 +85,26 @@ func (r *Repo) GetGood(ctx context.Context, id int, projectID int) (entity.Good,
 	var good entity.Good
 
 	query := `
-SELECT id, project_id, name, COALESCE(description, ''), priority, removed, created_at
+SELECT id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at
 FROM goods
 WHERE id = $1 AND project_id = $2
 	
 `
-	row := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, id, projectID)
-
-	err := row.Scan(
-		&good.ID,
-		&good.ProjectID,
-		&good.Name,
-		&good.Description,
-		&good.Priority,
-		&good.Removed,
-		&good.CreatedAt,
-	)
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		return tx.QueryRow(ctx, query, id, projectID).Scan(
+			&good.ID,
+			&good.ProjectID,
+			&good.Name,
+			&good.Description,
+			&good.Priority,
+			&good.Removed,
+			&good.Tags,
+			&good.CategoryID,
+			&good.Attributes,
+			&good.ExternalID,
+			&good.CreatedAt,
+		)
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return entity.Good{}, entity.ErrGoodNotFound
@@ -93,17 +116,30 @@ WHERE id = $1 AND project_id = $2
 	return good, nil
 }
 
-func (r *Repo) UpdateGood(ctx context.Context, id int, projectID int, goodUpdate entity.GoodUpdate) (entity.Good, error) {
-	var good entity.Good
+// UpdateGood returns the updated good and the good as it was before the update.
+func (r *Repo) UpdateGood(ctx context.Context, id int, projectID int, goodUpdate entity.GoodUpdate) (entity.Good, entity.Good, error) {
+	var good, previous entity.Good
 
 	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
 		queryCheck := `
-SELECT id FROM goods WHERE id = $1 AND project_id = $2 FOR UPDATE
+SELECT id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at
+FROM goods WHERE id = $1 AND project_id = $2 FOR UPDATE
 `
 		row := tx.QueryRow(ctx, queryCheck, id, projectID)
 
-		var goodID int
-		if err := row.Scan(&goodID); err != nil {
+		if err := row.Scan(
+			&previous.ID,
+			&previous.ProjectID,
+			&previous.Name,
+			&previous.Description,
+			&previous.Priority,
+			&previous.Removed,
+			&previous.Tags,
+			&previous.CategoryID,
+			&previous.Attributes,
+			&previous.ExternalID,
+			&previous.CreatedAt,
+		); err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return entity.ErrGoodNotFound
 			}
@@ -114,15 +150,19 @@ SELECT id FROM goods WHERE id = $1 AND project_id = $2 FOR UPDATE
 		queryUpdate := `
 UPDATE goods
 SET name = $1,
-	description = COALESCE($2, description)
+	description = COALESCE($2, description),
+	tags = COALESCE($5, tags),
+	attributes = COALESCE($6, attributes)
 WHERE id = $3 AND project_id = $4
-RETURNING id, project_id, name, COALESCE(description, ''), priority, removed, created_at		
+RETURNING id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at		
 `
 		row = tx.QueryRow(ctx, queryUpdate,
 			goodUpdate.Name,
 			goodUpdate.Description,
 			id,
 			projectID,
+			goodUpdate.Tags,
+			goodUpdate.Attributes,
 		)
 
 		if err := row.Scan(
@@ -132,6 +172,10 @@ RETURNING id, project_id, name, COALESCE(description, ''), priority, removed, cr
 			&good.Description,
 			&good.Priority,
 			&good.Removed,
+			&good.Tags,
+			&good.CategoryID,
+			&good.Attributes,
+			&good.ExternalID,
 			&good.CreatedAt,
 		); err != nil {
 			return fmt.Errorf("failed to scan updated good: %w", err)
@@ -141,13 +185,13 @@ RETURNING id, project_id, name, COALESCE(description, ''), priority, removed, cr
 	})
 	if err != nil {
 		if errors.Is(err, entity.ErrGoodNotFound) {
-			return entity.Good{}, fmt.Errorf("good is not found in UpdateGood(): %w", err)
+			return entity.Good{}, entity.Good{}, fmt.Errorf("good is not found in UpdateGood(): %w", err)
 		}
 
-		return entity.Good{}, fmt.Errorf("failed to update good: %w", err)
+		return entity.Good{}, entity.Good{}, fmt.Errorf("failed to update good: %w", err)
 	}
 
-	return good, nil
+	return good, previous, nil
 }
 
 func (r *Repo) DeleteGood(ctx context.Context, id int, projectID int) (entity.GoodDeleteResponse, error) {
@@ -172,14 +216,15 @@ SELECT id FROM goods WHERE id = $1 AND project_id = $2 FOR UPDATE
 UPDATE goods
 SET removed = true
 WHERE id = $1 AND project_id = $2
-RETURNING id, project_id, removed
+RETURNING id, project_id, removed, external_id
 `
 		row = tx.QueryRow(ctx, queryDelete, id, projectID)
 
 		err := row.Scan(
 			&response.ID,
 			&response.CampaignID,
-			&response.Removed)
+			&response.Removed,
+			&response.ExternalID)
 		if err != nil {
 			return fmt.Errorf("failed to scan deleted good: %w", err)
 		}
@@ -204,7 +249,14 @@ func (r *Repo) GetGoods(ctx context.Context, request entity.ListRequest) ([]enti
 	)
 
 	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
-		row := tx.QueryRow(ctx, `SELECT COUNT(*), COUNT(*) FILTER (WHERE removed = true) FROM goods`)
+		filter, args := listFilter(request)
+
+		row := tx.QueryRow(ctx, `
+SELECT COUNT(*), COUNT(*) FILTER (WHERE removed = true)
+FROM goods
+WHERE `+filter,
+			args...,
+		)
 
 		err := row.Scan(&meta.Total, &meta.Removed)
 		if err != nil {
@@ -212,11 +264,12 @@ func (r *Repo) GetGoods(ctx context.Context, request entity.ListRequest) ([]enti
 		}
 
 		rows, err := tx.Query(ctx,
-			`SELECT id, project_id, name, COALESCE(description, ''), priority, removed, created_at
+			`SELECT id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at
 			FROM goods
+			WHERE `+filter+fmt.Sprintf(`
 			ORDER BY created_at DESC
-			LIMIT $1 OFFSET $2`,
-			request.Limit, request.Offset,
+			LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2),
+			append(args, request.Limit, request.Offset)...,
 		)
 		if err != nil {
 			return fmt.Errorf("error while quering in GetGoods(): %w", err)
@@ -233,6 +286,10 @@ func (r *Repo) GetGoods(ctx context.Context, request entity.ListRequest) ([]enti
 				&good.Description,
 				&good.Priority,
 				&good.Removed,
+				&good.Tags,
+				&good.CategoryID,
+				&good.Attributes,
+				&good.ExternalID,
 				&good.CreatedAt,
 			); err != nil {
 				return fmt.Errorf("error scanning good: %w", err)
@@ -253,6 +310,64 @@ func (r *Repo) GetGoods(ctx context.Context, request entity.ListRequest) ([]enti
 	return goods, meta, nil
 }
 
+// listFilter returns the WHERE condition of the goods list and its arguments.
+func listFilter(request entity.ListRequest) (string, []any) {
+	conditions := []string{"($1 = 0 OR project_id = $1)"}
+	args := []any{request.ProjectID}
+
+	if request.CategoryID > 0 {
+		args = append(args, request.CategoryID)
+		conditions = append(conditions, fmt.Sprintf(`category_id IN (
+	SELECT id FROM categories WHERE path LIKE (SELECT path FROM categories WHERE id = $%d) || '%%'
+)`, len(args)))
+	}
+
+	if len(request.Tags) > 0 {
+		operator := "&&"
+		if request.TagMode == entity.TagModeAll {
+			operator = "@>"
+		}
+
+		args = append(args, request.Tags)
+		conditions = append(conditions, fmt.Sprintf("tags %s $%d", operator, len(args)))
+	}
+
+	for _, filter := range request.Attributes {
+		condition, filterArgs := attributeCondition(filter, len(args))
+
+		args = append(args, filterArgs...)
+		conditions = append(conditions, condition)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// attributeCondition returns the condition of the attribute filter, whose
+// arguments are numbered after n. Values are compared as JSONB, so that eq
+// and ne can use the index of attributes.
+func attributeCondition(filter entity.AttributeFilter, n int) (string, []any) {
+	switch filter.Operator {
+	case entity.AttributeOpEq:
+		return fmt.Sprintf("attributes @> $%d::jsonb", n+1), []any{map[string]any{filter.Name: filter.Values[0]}}
+	case entity.AttributeOpNe:
+		return fmt.Sprintf("NOT attributes @> $%d::jsonb", n+1), []any{map[string]any{filter.Name: filter.Values[0]}}
+	case entity.AttributeOpIn:
+		return fmt.Sprintf("$%d::jsonb @> jsonb_build_array(attributes -> $%d::text)", n+1, n+2),
+			[]any{filter.Values, filter.Name}
+	default:
+		operator := map[string]string{
+			entity.AttributeOpGt:  ">",
+			entity.AttributeOpGte: ">=",
+			entity.AttributeOpLt:  "<",
+			entity.AttributeOpLte: "<=",
+		}[filter.Operator]
+
+		return fmt.Sprintf("jsonb_typeof(attributes -> $%d::text) = 'number' AND attributes -> $%d::text %s $%d::jsonb",
+				n+1, n+1, operator, n+2),
+			[]any{filter.Name, filter.Values[0]}
+	}
+}
+
 //nolint:funlen
 func (r *Repo) Reprioritize(ctx context.Context, id int, projectID int, req entity.PriorityRequest) ([]entity.Priority, error) {
 	var updatedPriorities []entity.Priority
@@ -278,10 +393,12 @@ WHERE TRUE
 	AND priority >= $2 
 	AND priority < $3
 	AND id != $4
-RETURNING id, priority
+	AND ($5 OR category_id IS NOT DISTINCT FROM (SELECT category_id FROM goods WHERE id = $4))
+RETURNING id, priority, external_id
 `
 
-			rows, err := tx.Query(ctx, updateQuery, projectID, newPriority, currentPriority, id)
+			rows, err := tx.Query(ctx, updateQuery, projectID, newPriority, currentPriority, id,
+				r.cfg.PriorityScope != entity.PriorityScopeCategory)
 			if err != nil {
 				return fmt.Errorf("failed to update priorities: %w", err)
 			}
@@ -290,26 +407,33 @@ RETURNING id, priority
 
 			for rows.Next() {
 				var p entity.Priority
-				if err := rows.Scan(&p.ID, &p.Priority); err != nil {
+				if err := rows.Scan(&p.ID, &p.Priority, &p.ExternalID); err != nil {
 					return fmt.Errorf("failed to scan updated priority: %w", err)
 				}
 
+				p.PreviousPriority = p.Priority - 1
+
 				updatedPriorities = append(updatedPriorities, p)
 			}
 		}
 
-		_, err = tx.Exec(ctx, `
+		var externalID *string
+
+		err = tx.QueryRow(ctx, `
 			UPDATE goods
 			SET priority = $1
-			WHERE id = $2 AND project_id = $3`,
-			newPriority, id, projectID)
+			WHERE id = $2 AND project_id = $3
+			RETURNING external_id`,
+			newPriority, id, projectID).Scan(&externalID)
 		if err != nil {
 			return fmt.Errorf("failed to update target priority: %w", err)
 		}
 
 		updatedPriorities = append(updatedPriorities, entity.Priority{
-			ID:       id,
-			Priority: newPriority,
+			ID:               id,
+			Priority:         newPriority,
+			PreviousPriority: currentPriority,
+			ExternalID:       externalID,
 		})
 
 		return nil
@@ -341,3 +465,21 @@ func (r *Repo) getCurrentPriority(ctx context.Context, tx postgres.Transaction,
 
 	return currentPriority, nil
 }
+
+// nextPriority returns the priority that puts a good last among the goods of
+// the project or, with the category priority scope, of the category.
+func (r *Repo) nextPriority(ctx context.Context, tx postgres.Transaction, projectID int, categoryID *int) (int, error) {
+	var priority int
+
+	row := tx.QueryRow(ctx, `
+SELECT COALESCE(MAX(priority), 0) + 1
+FROM goods
+WHERE project_id = $1 AND ($3 OR category_id IS NOT DISTINCT FROM $2)`,
+		projectID, categoryID, r.cfg.PriorityScope != entity.PriorityScopeCategory,
+	)
+	if err := row.Scan(&priority); err != nil {
+		return 0, fmt.Errorf("failed to get max priority: %w", err)
+	}
+
+	return priority, nil
+}
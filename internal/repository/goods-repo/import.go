@@ -0,0 +1,362 @@
+package goodsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+)
+
+var errDryRun = errors.New("dry run")
+
+// ImportGoods applies the rows in one transaction: new goods are copied into
+// the table and the goods of upserted rows, found by id or external id, are
+// updated. Rows of unknown goods or categories and rows of used external ids
+// are returned as errors and skipped. A dry run is rolled back.
+func (r *Repo) ImportGoods(ctx context.Context, request entity.ImportRequest, rows []entity.ImportRow) (entity.ImportResult, error) {
+	var result entity.ImportResult
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		result = entity.ImportResult{}
+
+		categories, err := importedCategories(ctx, tx, request.ProjectID, rows)
+		if err != nil {
+			return err
+		}
+
+		previous, external, err := importedGoods(ctx, tx, request.ProjectID, rows)
+		if err != nil {
+			return err
+		}
+
+		var (
+			inserts       []entity.ImportRow
+			updates       []entity.ImportRow
+			lines         = make(map[int]int)
+			externalLines = make(map[string]int)
+		)
+
+		for _, row := range rows {
+			if row.CategoryID != nil && !categories[*row.CategoryID] {
+				result.Errors = append(result.Errors, entity.ImportError{Line: row.Line, Error: entity.ErrCategoryNotFound.Error()})
+
+				continue
+			}
+
+			if row.ExternalID != nil {
+				if line, ok := externalLines[*row.ExternalID]; ok {
+					result.Errors = append(result.Errors, entity.ImportError{
+						Line:  row.Line,
+						Error: fmt.Sprintf("external id %q is already used by line %d", *row.ExternalID, line),
+					})
+
+					continue
+				}
+
+				externalLines[*row.ExternalID] = row.Line
+
+				if good, ok := external[*row.ExternalID]; ok {
+					if request.Mode == entity.ImportModeUpsert && row.ID == 0 {
+						row.ID = good.ID
+					}
+
+					if row.ID != good.ID {
+						result.Errors = append(result.Errors, entity.ImportError{Line: row.Line, Error: entity.ErrExternalIDExists.Error()})
+
+						continue
+					}
+				}
+			}
+
+			if row.ID == 0 {
+				inserts = append(inserts, row)
+
+				continue
+			}
+
+			if _, ok := previous[row.ID]; !ok {
+				result.Errors = append(result.Errors, entity.ImportError{Line: row.Line, Error: entity.ErrGoodNotFound.Error()})
+
+				continue
+			}
+
+			if line, ok := lines[row.ID]; ok {
+				result.Errors = append(result.Errors, entity.ImportError{
+					Line:  row.Line,
+					Error: fmt.Sprintf("good %d is already updated by line %d", row.ID, line),
+				})
+
+				continue
+			}
+
+			lines[row.ID] = row.Line
+			updates = append(updates, row)
+		}
+
+		if result.Created, err = r.insertImported(ctx, tx, request, inserts); err != nil {
+			return err
+		}
+
+		for _, row := range updates {
+			good, err := updateImported(ctx, tx, request, row)
+			if err != nil {
+				return err
+			}
+
+			result.Updated = append(result.Updated, entity.UpdatedGood{Good: good, Previous: previous[row.ID]})
+		}
+
+		if request.DryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return entity.ImportResult{}, fmt.Errorf("failed to import goods: %w", err)
+	}
+
+	return result, nil
+}
+
+// importedCategories returns the categories of the rows that belong to the project.
+func importedCategories(ctx context.Context, tx postgres.Transaction, projectID int, rows []entity.ImportRow) (map[int]bool, error) {
+	ids := make([]int, 0)
+
+	for _, row := range rows {
+		if row.CategoryID != nil {
+			ids = append(ids, *row.CategoryID)
+		}
+	}
+
+	categories := make(map[int]bool, len(ids))
+
+	if len(ids) == 0 {
+		return categories, nil
+	}
+
+	found, err := tx.Query(ctx, `SELECT id FROM categories WHERE project_id = $1 AND id = ANY($2)`, projectID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	ids, err = pgx.CollectRows(found, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read categories: %w", err)
+	}
+
+	for _, id := range ids {
+		categories[id] = true
+	}
+
+	return categories, nil
+}
+
+// importedGoods locks the goods of the project with the ids and external ids
+// of the rows and returns them by id and by external id.
+func importedGoods(
+	ctx context.Context, tx postgres.Transaction, projectID int, rows []entity.ImportRow,
+) (map[int]entity.Good, map[string]entity.Good, error) {
+	var (
+		ids         = make([]int, 0)
+		externalIDs = make([]string, 0)
+		byID        = make(map[int]entity.Good)
+		byExternal  = make(map[string]entity.Good)
+	)
+
+	for _, row := range rows {
+		if row.ID != 0 {
+			ids = append(ids, row.ID)
+		}
+
+		if row.ExternalID != nil {
+			externalIDs = append(externalIDs, *row.ExternalID)
+		}
+	}
+
+	if len(ids) == 0 && len(externalIDs) == 0 {
+		return byID, byExternal, nil
+	}
+
+	goods, err := queryGoods(ctx, tx, `
+SELECT id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at
+FROM goods
+WHERE project_id = $1 AND (id = ANY($2) OR external_id = ANY($3))
+FOR UPDATE`,
+		projectID, ids, externalIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, good := range goods {
+		byID[good.ID] = good
+
+		if good.ExternalID != nil {
+			byExternal[*good.ExternalID] = good
+		}
+	}
+
+	return byID, byExternal, nil
+}
+
+// insertImported copies the new goods into the table. Their ids are taken
+// from the sequence beforehand to read the goods back.
+func (r *Repo) insertImported(
+	ctx context.Context, tx postgres.Transaction, request entity.ImportRequest, rows []entity.ImportRow,
+) ([]entity.Good, error) {
+	if len(rows) == 0 {
+		return []entity.Good{}, nil
+	}
+
+	ids, err := tx.Query(ctx, `SELECT nextval(pg_get_serial_sequence('goods', 'id')) FROM generate_series(1, $1)`, len(rows))
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate good ids: %w", err)
+	}
+
+	goodIDs, err := pgx.CollectRows(ids, pgx.RowTo[int])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read good ids: %w", err)
+	}
+
+	priorities := make(map[int]int)
+	values := make([][]any, 0, len(rows))
+
+	for i, row := range rows {
+		priority := row.Priority
+
+		if request.Priorities == entity.ImportPrioritiesAppend {
+			priority, err = r.appendedPriority(ctx, tx, request.ProjectID, row.CategoryID, priorities)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		values = append(values, []any{
+			goodIDs[i], request.ProjectID, row.Name, row.Description, priority, row.Tags, row.CategoryID, row.Attributes, row.ExternalID,
+		})
+	}
+
+	// Row-level security doesn't allow COPY into goods, so the goods are
+	// copied into a temporary table first.
+	if _, err := tx.Exec(ctx, `
+CREATE TEMPORARY TABLE imported_goods ON COMMIT DROP AS
+SELECT id, project_id, name, description, priority, tags, category_id, attributes, external_id
+FROM goods
+WITH NO DATA`); err != nil {
+		return nil, fmt.Errorf("failed to create imported goods table: %w", err)
+	}
+
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"imported_goods"},
+		[]string{"id", "project_id", "name", "description", "priority", "tags", "category_id", "attributes", "external_id"},
+		pgx.CopyFromRows(values),
+	); err != nil {
+		return nil, fmt.Errorf("failed to copy goods: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+INSERT INTO goods (id, project_id, name, description, priority, tags, category_id, attributes, external_id)
+SELECT id, project_id, name, description, priority, tags, category_id, attributes, external_id
+FROM imported_goods`); err != nil {
+		return nil, fmt.Errorf("failed to insert goods: %w", err)
+	}
+
+	return queryGoods(ctx, tx, `
+SELECT id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at
+FROM goods
+WHERE id = ANY($1)
+ORDER BY id`,
+		goodIDs)
+}
+
+// appendedPriority returns the next priority after the goods of the scope,
+// counting the goods already imported to it.
+func (r *Repo) appendedPriority(
+	ctx context.Context, tx postgres.Transaction, projectID int, categoryID *int, priorities map[int]int,
+) (int, error) {
+	scope := 0
+	if r.cfg.PriorityScope == entity.PriorityScopeCategory && categoryID != nil {
+		scope = *categoryID
+	}
+
+	priority, ok := priorities[scope]
+	if !ok {
+		var err error
+
+		priority, err = r.nextPriority(ctx, tx, projectID, categoryID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	priorities[scope] = priority + 1
+
+	return priority, nil
+}
+
+// updateImported replaces the good with the row. The priority is only
+// changed if it is taken from the file, and the external id if the row has one.
+func updateImported(ctx context.Context, tx postgres.Transaction, request entity.ImportRequest, row entity.ImportRow) (entity.Good, error) {
+	priority := 0
+	if request.Priorities == entity.ImportPrioritiesFile {
+		priority = row.Priority
+	}
+
+	goods, err := queryGoods(ctx, tx, `
+UPDATE goods
+SET name = $3, description = $4, tags = $5, category_id = $6, attributes = $7,
+	priority = CASE WHEN $8::int > 0 THEN $8::int ELSE priority END, external_id = COALESCE($9, external_id)
+WHERE id = $1 AND project_id = $2
+RETURNING id, project_id, name, COALESCE(description, ''), priority, removed, tags, category_id, attributes, external_id, created_at`,
+		row.ID, request.ProjectID, row.Name, row.Description, row.Tags, row.CategoryID, row.Attributes, priority, row.ExternalID)
+	if err != nil {
+		return entity.Good{}, err
+	}
+
+	if len(goods) == 0 {
+		return entity.Good{}, entity.ErrGoodNotFound
+	}
+
+	return goods[0], nil
+}
+
+func queryGoods(ctx context.Context, tx postgres.Transaction, query string, args ...any) ([]entity.Good, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goods: %w", err)
+	}
+
+	defer rows.Close()
+
+	goods := make([]entity.Good, 0)
+
+	for rows.Next() {
+		var good entity.Good
+		if err := rows.Scan(
+			&good.ID,
+			&good.ProjectID,
+			&good.Name,
+			&good.Description,
+			&good.Priority,
+			&good.Removed,
+			&good.Tags,
+			&good.CategoryID,
+			&good.Attributes,
+			&good.ExternalID,
+			&good.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan good: %w", err)
+		}
+
+		goods = append(goods, good)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read goods: %w", err)
+	}
+
+	return goods, nil
+}
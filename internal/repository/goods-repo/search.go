@@ -0,0 +1,151 @@
+package goodsrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	undefinedObject = "42704"
+
+	highlightStart   = "<mark>"
+	highlightStop    = "</mark>"
+	highlightOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop +
+		", MaxFragments=2, MaxWords=20, MinWords=5"
+)
+
+// SetSearchLanguage sets the text search configuration of goods, such as
+// english or simple. When it changes, the search vectors of all goods are
+// rebuilt.
+func (r *Repo) SetSearchLanguage(ctx context.Context, language string) error {
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		tag, err := tx.Exec(ctx, `UPDATE search_settings SET language = $1::regconfig WHERE language <> $1::regconfig`,
+			language)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == undefinedObject {
+				return fmt.Errorf("%w: %s", entity.ErrUnknownSearchLanguage, language)
+			}
+
+			return fmt.Errorf("failed to update search language: %w", err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		// Updating the name fires the trigger that builds the search vector.
+		tag, err = tx.Exec(ctx, `UPDATE goods SET name = name`)
+		if err != nil {
+			return fmt.Errorf("failed to rebuild search vectors: %w", err)
+		}
+
+		log.Info().Msgf("rebuilt search vectors of %d goods for language %s", tag.RowsAffected(), language)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set search language: %w", err)
+	}
+
+	return nil
+}
+
+// SearchGoods finds the goods of the project that are not removed by the
+// words of their name and description, or by names and descriptions similar
+// to the query to tolerate typos. Returns the page of results ranked by
+// relevance and the number of all results.
+func (r *Repo) SearchGoods(ctx context.Context, request entity.SearchRequest) ([]entity.SearchResult, int, error) {
+	var (
+		results = make([]entity.SearchResult, 0)
+		total   int
+	)
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		rows, err := tx.Query(ctx, `
+WITH search AS (
+	SELECT language, websearch_to_tsquery(language, $2) AS query
+	FROM search_settings
+)
+SELECT g.id, g.project_id, g.name, COALESCE(g.description, ''), g.priority, g.removed, g.tags, g.category_id,
+	g.attributes, g.external_id, g.created_at,
+	ts_rank_cd(g.search_vector, search.query) + GREATEST(word_similarity($2, g.name), word_similarity($2, g.description)) AS rank,
+	ts_headline(search.language, g.name, search.query, $3),
+	ts_headline(search.language, COALESCE(g.description, ''), search.query, $3),
+	COUNT(*) OVER ()
+FROM goods g, search
+WHERE g.project_id = $1 AND NOT g.removed
+	AND (g.search_vector @@ search.query OR $2 <% g.name OR $2 <% g.description)
+ORDER BY rank DESC, g.id
+LIMIT $4 OFFSET $5`,
+			request.ProjectID, request.Query, highlightOptions, request.Limit, request.Offset,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to query goods: %w", err)
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var result entity.SearchResult
+			if err := rows.Scan(
+				&result.Good.ID,
+				&result.Good.ProjectID,
+				&result.Good.Name,
+				&result.Good.Description,
+				&result.Good.Priority,
+				&result.Good.Removed,
+				&result.Good.Tags,
+				&result.Good.CategoryID,
+				&result.Good.Attributes,
+				&result.Good.ExternalID,
+				&result.Good.CreatedAt,
+				&result.Rank,
+				&result.Highlight.Name,
+				&result.Highlight.Description,
+				&total,
+			); err != nil {
+				return fmt.Errorf("failed to scan found good: %w", err)
+			}
+
+			result.Highlight.Name = escapeHighlight(result.Highlight.Name)
+			result.Highlight.Description = escapeHighlight(result.Highlight.Description)
+
+			results = append(results, result)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to read found goods: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search goods: %w", err)
+	}
+
+	return results, total, nil
+}
+
+// escapeHighlight escapes the HTML of a headline except the highlight tags.
+func escapeHighlight(headline string) string {
+	parts := strings.Split(headline, highlightStart)
+	for i, part := range parts {
+		marked := strings.Split(part, highlightStop)
+		for j := range marked {
+			marked[j] = html.EscapeString(marked[j])
+		}
+
+		parts[i] = strings.Join(marked, highlightStop)
+	}
+
+	return strings.Join(parts, highlightStart)
+}
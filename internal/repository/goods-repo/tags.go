@@ -0,0 +1,164 @@
+package goodsrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+)
+
+// GetTags returns the tags of the project's goods that are not removed, with the number of goods of each.
+func (r *Repo) GetTags(ctx context.Context, projectID int) ([]entity.Tag, error) {
+	tags := make([]entity.Tag, 0)
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		rows, err := tx.Query(ctx, `
+SELECT tag, COUNT(*)
+FROM goods, unnest(tags) AS tag
+WHERE project_id = $1 AND NOT removed
+GROUP BY tag
+ORDER BY tag`,
+			projectID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to query tags: %w", err)
+		}
+
+		defer rows.Close()
+
+		for rows.Next() {
+			var tag entity.Tag
+			if err := rows.Scan(&tag.Name, &tag.Goods); err != nil {
+				return fmt.Errorf("failed to scan tag: %w", err)
+			}
+
+			tags = append(tags, tag)
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("failed to read tags: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// RenameTag renames the tag on all goods of the project. It fails if the new
+// name is already used in the project: use MergeTags to combine tags.
+func (r *Repo) RenameTag(ctx context.Context, projectID int, from, to string) ([]entity.ChangedGood, error) {
+	var goods []entity.ChangedGood
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		var exists bool
+
+		row := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM goods WHERE project_id = $1 AND tags @> ARRAY[$2]::varchar[])`,
+			projectID, to)
+		if err := row.Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check tag: %w", err)
+		}
+
+		if exists {
+			return entity.ErrTagExists
+		}
+
+		var err error
+
+		goods, err = replaceTags(ctx, tx, projectID, []string{from}, to)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	return goods, nil
+}
+
+// MergeTags replaces the source tags with the target on all goods of the project.
+func (r *Repo) MergeTags(ctx context.Context, projectID int, sources []string, target string) ([]entity.ChangedGood, error) {
+	var goods []entity.ChangedGood
+
+	err := r.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+		var err error
+
+		goods, err = replaceTags(ctx, tx, projectID, sources, target)
+
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	return goods, nil
+}
+
+// replaceTags returns the changed goods with their previous tags, or
+// ErrTagNotFound if none of the goods have the sources.
+func replaceTags(
+	ctx context.Context, tx postgres.Transaction, projectID int, sources []string, target string,
+) ([]entity.ChangedGood, error) {
+	rows, err := tx.Query(ctx, `
+WITH previous AS (
+	SELECT id, tags
+	FROM goods
+	WHERE project_id = $1 AND tags && $2::varchar[]
+	FOR UPDATE
+)
+UPDATE goods
+SET tags = ARRAY(
+	SELECT DISTINCT CASE WHEN tag = ANY($2::varchar[]) THEN $3 ELSE tag END
+	FROM unnest(goods.tags) AS tag
+	ORDER BY 1
+)
+FROM previous
+WHERE goods.id = previous.id
+RETURNING goods.id, goods.project_id, goods.name, COALESCE(goods.description, ''), goods.priority,
+	goods.removed, goods.tags, goods.category_id, goods.attributes, goods.external_id, goods.created_at, previous.tags`,
+		projectID, sources, target,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replace tags: %w", err)
+	}
+
+	defer rows.Close()
+
+	var goods []entity.ChangedGood
+
+	for rows.Next() {
+		var good entity.ChangedGood
+		if err := rows.Scan(
+			&good.ID,
+			&good.ProjectID,
+			&good.Name,
+			&good.Description,
+			&good.Priority,
+			&good.Removed,
+			&good.Tags,
+			&good.CategoryID,
+			&good.Attributes,
+			&good.ExternalID,
+			&good.CreatedAt,
+			&good.PrevTags,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan retagged good: %w", err)
+		}
+
+		goods = append(goods, good)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read retagged goods: %w", err)
+	}
+
+	if len(goods) == 0 {
+		return nil, entity.ErrTagNotFound
+	}
+
+	return goods, nil
+}
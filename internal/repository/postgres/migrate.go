@@ -0,0 +1,244 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// migrationLockID is the key of the advisory lock held while migrating.
+const migrationLockID = 4172730516
+
+//go:embed migrations
+var migrations embed.FS
+
+var ErrNoMigrationToRedo = errors.New("no applied migration to redo")
+
+// MigrationStatus is a migration from the migrations directory. AppliedAt is nil if it is pending.
+type MigrationStatus struct {
+	ID        string
+	AppliedAt *time.Time
+}
+
+// PlannedMigration is a migration that would run, with its SQL.
+type PlannedMigration struct {
+	ID        string
+	Direction migrate.MigrationDirection
+	Queries   []string
+}
+
+// Migrate applies all pending migrations up, or rolls back all applied migrations down.
+func (d *DataStore) Migrate(direction migrate.MigrationDirection) error {
+	if _, err := d.MigrateMax(context.Background(), direction, 0); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MigrateMax applies or rolls back at most limit migrations, all of them if
+// limit is 0, and returns how many ran. It holds an advisory lock, so replicas
+// migrating on start wait for each other instead of racing.
+func (d *DataStore) MigrateMax(ctx context.Context, direction migrate.MigrationDirection, limit int) (int, error) {
+	var applied int
+
+	err := d.withMigrationLock(ctx, func(conn *sql.DB) (err error) {
+		applied, err = migrate.ExecMaxContext(ctx, conn, "postgres", migrationSource(), direction, limit)
+		if err != nil {
+			return fmt.Errorf("failed to apply migrations: %w", err)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// RedoMigration rolls back the last applied migration and applies it again.
+// It returns the ID of the migration.
+func (d *DataStore) RedoMigration(ctx context.Context) (string, error) {
+	var id string
+
+	err := d.withMigrationLock(ctx, func(conn *sql.DB) error {
+		planned, _, err := migrate.PlanMigration(conn, "postgres", migrationSource(), migrate.Down, 1)
+		if err != nil {
+			return fmt.Errorf("failed to plan migrations: %w", err)
+		}
+
+		if len(planned) == 0 {
+			return ErrNoMigrationToRedo
+		}
+
+		id = planned[0].Id
+
+		if _, err := migrate.ExecMaxContext(ctx, conn, "postgres", migrationSource(), migrate.Down, 1); err != nil {
+			return fmt.Errorf("failed to roll back %s: %w", id, err)
+		}
+
+		if _, err := migrate.ExecMaxContext(ctx, conn, "postgres", migrationSource(), migrate.Up, 1); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", id, err)
+		}
+
+		return nil
+	})
+
+	return id, err
+}
+
+// PlanMigrations returns the migrations MigrateMax would run without running them.
+func (d *DataStore) PlanMigrations(direction migrate.MigrationDirection, limit int) ([]PlannedMigration, error) {
+	conn, err := d.openSQL()
+	if err != nil {
+		return nil, err
+	}
+	defer closeSQL(conn)
+
+	planned, _, err := migrate.PlanMigration(conn, "postgres", migrationSource(), direction, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan migrations: %w", err)
+	}
+
+	result := make([]PlannedMigration, 0, len(planned))
+	for _, migration := range planned {
+		result = append(result, PlannedMigration{
+			ID:        migration.Id,
+			Direction: direction,
+			Queries:   migration.Queries,
+		})
+	}
+
+	return result, nil
+}
+
+// PlanRedo returns the rollback and the migration RedoMigration would run.
+func (d *DataStore) PlanRedo() ([]PlannedMigration, error) {
+	conn, err := d.openSQL()
+	if err != nil {
+		return nil, err
+	}
+	defer closeSQL(conn)
+
+	planned, _, err := migrate.PlanMigration(conn, "postgres", migrationSource(), migrate.Down, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan migrations: %w", err)
+	}
+
+	if len(planned) == 0 {
+		return nil, ErrNoMigrationToRedo
+	}
+
+	return []PlannedMigration{
+		{ID: planned[0].Id, Direction: migrate.Down, Queries: planned[0].Down},
+		{ID: planned[0].Id, Direction: migrate.Up, Queries: planned[0].Up},
+	}, nil
+}
+
+func (d *DataStore) MigrationStatus() ([]MigrationStatus, error) {
+	conn, err := d.openSQL()
+	if err != nil {
+		return nil, err
+	}
+	defer closeSQL(conn)
+
+	found, err := migrationSource().FindMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find migrations: %w", err)
+	}
+
+	records, err := migrate.GetMigrationRecords(conn, "postgres")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	applied := make(map[string]time.Time, len(records))
+	for _, record := range records {
+		applied[record.Id] = record.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(found))
+
+	for _, migration := range found {
+		status := MigrationStatus{ID: migration.Id}
+		if appliedAt, ok := applied[migration.Id]; ok {
+			status.AppliedAt = &appliedAt
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// withMigrationLock runs fn while one connection of the pool holds the migration advisory lock.
+func (d *DataStore) withMigrationLock(ctx context.Context, fn func(conn *sql.DB) error) (err error) {
+	conn, err := d.openSQL()
+	if err != nil {
+		return err
+	}
+	defer closeSQL(conn)
+
+	lockConn, err := conn.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get connection: %w", err)
+	}
+
+	defer func() {
+		if closeErr := lockConn.Close(); closeErr != nil {
+			log.Error().Err(closeErr).Msg("failed to close lock connection")
+		}
+	}()
+
+	if _, err := lockConn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+
+	defer func() {
+		_, unlockErr := lockConn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+		if unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to release migration lock: %w", unlockErr))
+		}
+	}()
+
+	return fn(conn)
+}
+
+func (d *DataStore) openSQL() (*sql.DB, error) {
+	conn, err := sql.Open("pgx", d.dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sql: %w", err)
+	}
+
+	return conn, nil
+}
+
+func closeSQL(conn *sql.DB) {
+	if err := conn.Close(); err != nil {
+		log.Error().Err(err).Msg("failed to close database")
+	}
+}
+
+func migrationSource() migrate.AssetMigrationSource {
+	return migrate.AssetMigrationSource{
+		Asset: migrations.ReadFile,
+		AssetDir: func(path string) ([]string, error) {
+			dirEntry, err := migrations.ReadDir(path)
+			if err != nil {
+				return nil, fmt.Errorf("migrations reading failed: %w", err)
+			}
+
+			entries := make([]string, 0)
+			for _, entry := range dirEntry {
+				entries = append(entries, entry.Name())
+			}
+
+			return entries, nil
+		},
+		Dir: "migrations",
+	}
+}
@@ -0,0 +1,34 @@
+-- +migrate Up
+CREATE TABLE webhooks
+(
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER REFERENCES projects (id) NOT NULL,
+    url VARCHAR NOT NULL,
+    secret VARCHAR NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    disabled_at TIMESTAMP
+);
+
+CREATE TABLE webhook_deliveries
+(
+    id SERIAL PRIMARY KEY,
+    webhook_id INTEGER REFERENCES webhooks (id) ON DELETE CASCADE NOT NULL,
+    operation VARCHAR NOT NULL,
+    good_id INTEGER NOT NULL,
+    attempt INTEGER NOT NULL,
+    status_code INTEGER,
+    error VARCHAR,
+    success BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhooks_project_id ON webhooks(project_id);
+CREATE INDEX idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_webhook_deliveries_webhook_id;
+DROP INDEX IF EXISTS idx_webhooks_project_id;
+DROP TABLE webhook_deliveries;
+DROP TABLE webhooks;
@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE api_keys
+(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR NOT NULL,
+    key_prefix VARCHAR NOT NULL,
+    key_hash VARCHAR NOT NULL,
+    project_ids INTEGER[] NOT NULL DEFAULT '{}',
+    scopes VARCHAR[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rotated_at TIMESTAMP,
+    revoked_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_api_keys_key_hash ON api_keys(key_hash);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_api_keys_key_hash;
+DROP TABLE api_keys;
@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE goods ADD COLUMN tags VARCHAR[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_goods_tags ON goods USING GIN (tags);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_goods_tags;
+ALTER TABLE goods DROP COLUMN tags;
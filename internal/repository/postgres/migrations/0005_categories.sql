@@ -0,0 +1,24 @@
+-- +migrate Up
+CREATE TABLE categories
+(
+    id SERIAL PRIMARY KEY,
+    project_id INTEGER REFERENCES projects (id) NOT NULL,
+    parent_id INTEGER REFERENCES categories (id),
+    name VARCHAR NOT NULL,
+    path VARCHAR NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_categories_name ON categories(project_id, COALESCE(parent_id, 0), name);
+CREATE INDEX idx_categories_path ON categories(path varchar_pattern_ops);
+
+ALTER TABLE goods ADD COLUMN category_id INTEGER REFERENCES categories (id);
+
+CREATE INDEX idx_goods_category_id ON goods(category_id);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_goods_category_id;
+ALTER TABLE goods DROP COLUMN category_id;
+DROP INDEX IF EXISTS idx_categories_path;
+DROP INDEX IF EXISTS idx_categories_name;
+DROP TABLE categories;
@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE attribute_schemas
+(
+    project_id INTEGER PRIMARY KEY REFERENCES projects (id),
+    attributes JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE goods ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_goods_attributes ON goods USING GIN (attributes jsonb_path_ops);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_goods_attributes;
+ALTER TABLE goods DROP COLUMN attributes;
+DROP TABLE attribute_schemas;
@@ -0,0 +1,47 @@
+-- +migrate Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE search_settings
+(
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    language REGCONFIG NOT NULL DEFAULT 'simple'
+);
+
+INSERT INTO search_settings DEFAULT VALUES;
+
+ALTER TABLE goods ADD COLUMN search_vector TSVECTOR NOT NULL DEFAULT '';
+
+-- +migrate StatementBegin
+CREATE FUNCTION goods_search_vector() RETURNS TRIGGER AS $$
+DECLARE
+    search_language REGCONFIG;
+BEGIN
+    SELECT language INTO search_language FROM search_settings;
+
+    NEW.search_vector :=
+        setweight(to_tsvector(search_language, NEW.name), 'A') ||
+        setweight(to_tsvector(search_language, COALESCE(NEW.description, '')), 'B');
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+CREATE TRIGGER goods_search_vector
+    BEFORE INSERT OR UPDATE OF name, description ON goods
+    FOR EACH ROW EXECUTE FUNCTION goods_search_vector();
+
+UPDATE goods SET name = name;
+
+CREATE INDEX idx_goods_search_vector ON goods USING GIN (search_vector);
+CREATE INDEX idx_goods_name_trgm ON goods USING GIN (name gin_trgm_ops);
+CREATE INDEX idx_goods_description_trgm ON goods USING GIN (description gin_trgm_ops);
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_goods_description_trgm;
+DROP INDEX IF EXISTS idx_goods_name_trgm;
+DROP INDEX IF EXISTS idx_goods_search_vector;
+DROP TRIGGER IF EXISTS goods_search_vector ON goods;
+DROP FUNCTION IF EXISTS goods_search_vector();
+ALTER TABLE goods DROP COLUMN search_vector;
+DROP TABLE search_settings;
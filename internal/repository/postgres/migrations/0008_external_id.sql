@@ -0,0 +1,8 @@
+-- +migrate Up
+ALTER TABLE goods ADD COLUMN external_id VARCHAR(128);
+
+ALTER TABLE goods ADD CONSTRAINT goods_project_external_id_key UNIQUE (project_id, external_id);
+
+-- +migrate Down
+ALTER TABLE goods DROP CONSTRAINT IF EXISTS goods_project_external_id_key;
+ALTER TABLE goods DROP COLUMN external_id;
@@ -0,0 +1,61 @@
+-- +migrate Up
+-- Transactions of requests switch to hezzl_tenant, which only sees the rows of
+-- the project in app.project_id. Roles are shared by the databases of the
+-- cluster, so it may already exist. Creating it needs CREATEROLE, and
+-- switching to it needs membership, which the database user gets here.
+-- +migrate StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'hezzl_tenant') THEN
+        IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = CURRENT_USER AND (rolcreaterole OR rolsuper)) THEN
+            RAISE EXCEPTION 'role hezzl_tenant does not exist and % can''t create it', CURRENT_USER
+                USING HINT = 'Grant CREATEROLE to the database user or create the role as a superuser: CREATE ROLE hezzl_tenant NOLOGIN';
+        END IF;
+
+        CREATE ROLE hezzl_tenant NOLOGIN;
+    END IF;
+
+    IF NOT pg_has_role(CURRENT_USER, 'hezzl_tenant', 'MEMBER') THEN
+        BEGIN
+            EXECUTE format('GRANT hezzl_tenant TO %I', CURRENT_USER);
+        EXCEPTION WHEN insufficient_privilege THEN
+            RAISE EXCEPTION '% is not a member of role hezzl_tenant and can''t grant it', CURRENT_USER
+                USING HINT = format('Grant it as a superuser or an admin of the role: GRANT hezzl_tenant TO %I', CURRENT_USER);
+        END;
+    END IF;
+END
+$$;
+-- +migrate StatementEnd
+
+-- The tenant role only gets the tables that transactions of requests use, all
+-- of them with policies. search_settings is read by the goods search trigger.
+GRANT SELECT, INSERT, UPDATE, DELETE ON goods, categories, attribute_schemas TO hezzl_tenant;
+GRANT SELECT ON search_settings TO hezzl_tenant;
+GRANT USAGE, SELECT ON SEQUENCE goods_id_seq, categories_id_seq TO hezzl_tenant;
+
+ALTER TABLE goods ENABLE ROW LEVEL SECURITY;
+ALTER TABLE categories ENABLE ROW LEVEL SECURITY;
+ALTER TABLE attribute_schemas ENABLE ROW LEVEL SECURITY;
+
+CREATE POLICY goods_tenant ON goods TO hezzl_tenant
+    USING (project_id = NULLIF(current_setting('app.project_id', true), '')::int);
+CREATE POLICY categories_tenant ON categories TO hezzl_tenant
+    USING (project_id = NULLIF(current_setting('app.project_id', true), '')::int);
+CREATE POLICY attribute_schemas_tenant ON attribute_schemas TO hezzl_tenant
+    USING (project_id = NULLIF(current_setting('app.project_id', true), '')::int);
+
+-- +migrate Down
+DROP POLICY IF EXISTS attribute_schemas_tenant ON attribute_schemas;
+DROP POLICY IF EXISTS categories_tenant ON categories;
+DROP POLICY IF EXISTS goods_tenant ON goods;
+
+ALTER TABLE attribute_schemas DISABLE ROW LEVEL SECURITY;
+ALTER TABLE categories DISABLE ROW LEVEL SECURITY;
+ALTER TABLE goods DISABLE ROW LEVEL SECURITY;
+
+REVOKE USAGE, SELECT ON SEQUENCE goods_id_seq, categories_id_seq FROM hezzl_tenant;
+REVOKE SELECT ON search_settings FROM hezzl_tenant;
+REVOKE SELECT, INSERT, UPDATE, DELETE ON goods, categories, attribute_schemas FROM hezzl_tenant;
+
+-- The role and the membership are kept, like the role itself: they belong to
+-- the cluster and may be used by the other databases of the user.
@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE UNIQUE INDEX idx_api_keys_name ON api_keys(name) WHERE revoked_at IS NULL;
+
+-- +migrate Down
+DROP INDEX IF EXISTS idx_api_keys_name;
@@ -2,21 +2,22 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
-	"embed"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/tracing"
 	"github.com/rs/zerolog/log"
-	migrate "github.com/rubenv/sql-migrate"
+	"go.opentelemetry.io/otel/trace"
 )
 
-//go:embed migrations
-var migrations embed.FS
+// tenantRole is the role the row-level security policies apply to.
+const tenantRole = "hezzl_tenant"
 
 type DataStore struct {
 	pool *pgxpool.Pool
@@ -28,7 +29,14 @@ type Config struct {
 }
 
 func New(ctx context.Context, cfg Config) (*DataStore, error) {
-	pool, err := pgxpool.New(ctx, cfg.Dsn)
+	poolConfig, err := pgxpool.ParseConfig(cfg.Dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database dsn: %w", err)
+	}
+
+	poolConfig.ConnConfig.Tracer = queryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -45,58 +53,6 @@ func New(ctx context.Context, cfg Config) (*DataStore, error) {
 	}, nil
 }
 
-func (d *DataStore) Migrate(direction migrate.MigrationDirection) error {
-	conn, err := sql.Open("pgx", d.dsn)
-	if err != nil {
-		return fmt.Errorf("failed to open sql: %w", err)
-	}
-
-	defer func() {
-		err := conn.Close()
-		if err != nil {
-			log.Error().Msg("failed to close database")
-		}
-	}()
-
-	files, err := migrations.ReadDir("migrations")
-	if err != nil {
-		return fmt.Errorf("failed to read migrations directory: %w", err)
-	}
-
-	for _, file := range files {
-		log.Info().Str("file", file.Name()).Msg("found migration file")
-	}
-
-	assetDir := func() func(string) ([]string, error) {
-		return func(path string) ([]string, error) {
-			dirEntry, err := migrations.ReadDir(path)
-			if err != nil {
-				return nil, fmt.Errorf("migrations reading failed: %w", err)
-			}
-
-			entries := make([]string, 0)
-			for _, entry := range dirEntry {
-				entries = append(entries, entry.Name())
-			}
-
-			return entries, nil
-		}
-	}()
-
-	asset := migrate.AssetMigrationSource{
-		Asset:    migrations.ReadFile,
-		AssetDir: assetDir,
-		Dir:      "migrations",
-	}
-
-	_, err = migrate.Exec(conn, "postgres", asset, direction)
-	if err != nil {
-		return fmt.Errorf("failed to count the number of migrations: %w", err)
-	}
-
-	return nil
-}
-
 func (d *DataStore) Truncate(ctx context.Context, tables ...string) error {
 	for _, table := range tables {
 		if _, err := d.pool.Exec(ctx, `DELETE FROM `+table); err != nil {
@@ -129,6 +85,7 @@ type Transaction interface {
 	Exec(ctx context.Context, query string, arguments ...any) (pgconn.CommandTag, error)
 	Query(ctx context.Context, sql string, arguments ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, arguments ...any) pgx.Row
+	CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, rows pgx.CopyFromSource) (int64, error)
 }
 
 type txCtxKey string
@@ -136,7 +93,14 @@ type txCtxKey string
 //nolint:gochecknoglobals
 var txKey txCtxKey = "tx"
 
-func (d *DataStore) WithinTransaction(ctx context.Context, fn func(ctx context.Context, tx Transaction) error) error {
+// WithinTransaction runs fn in a transaction. Transactions of principals
+// limited to a project only see the rows of the project, see setTenant.
+func (d *DataStore) WithinTransaction(ctx context.Context, fn func(ctx context.Context, tx Transaction) error) (err error) {
+	ctx, span := tracing.Start(ctx, "postgres transaction", trace.WithSpanKind(trace.SpanKindClient))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	tx, err := d.pool.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to start transaction: %w", err)
@@ -148,6 +112,10 @@ func (d *DataStore) WithinTransaction(ctx context.Context, fn func(ctx context.C
 		}
 	}()
 
+	if err := setTenant(ctx, tx); err != nil {
+		return err
+	}
+
 	if err := fn(context.WithValue(ctx, txKey, tx), tx); err != nil {
 		return fmt.Errorf("failed to execute transaction: %w", err)
 	}
@@ -159,6 +127,35 @@ func (d *DataStore) WithinTransaction(ctx context.Context, fn func(ctx context.C
 	return nil
 }
 
+// setTenant sets app.project_id to the project of the request principal and
+// switches to the tenant role, whose policies hide the rows of other projects
+// even from queries that forget to filter by project. Only principals of all
+// projects and work of the service itself, see entity.ContextWithSystem, see
+// all rows. Principals of several projects and callers without a principal see
+// none.
+func setTenant(ctx context.Context, tx pgx.Tx) error {
+	if entity.IsSystem(ctx) {
+		return nil
+	}
+
+	principal, _ := entity.PrincipalFromContext(ctx)
+	if principal.AllProjects {
+		return nil
+	}
+
+	projectID := ""
+	if len(principal.ProjectIDs) == 1 {
+		projectID = strconv.Itoa(principal.ProjectIDs[0])
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT set_config('app.project_id', $1, true), set_config('role', $2, true)`,
+		projectID, tenantRole); err != nil {
+		return fmt.Errorf("failed to set tenant: %w", err)
+	}
+
+	return nil
+}
+
 func (d *DataStore) GetTXFromContext(ctx context.Context) Transaction {
 	tx, ok := ctx.Value(txKey).(pgx.Tx)
 	if !ok {
@@ -167,3 +164,20 @@ func (d *DataStore) GetTXFromContext(ctx context.Context) Transaction {
 
 	return tx
 }
+
+// Close waits for the acquired connections to be released and closes the pool.
+func (d *DataStore) Close() {
+	d.pool.Close()
+}
+
+func (d *DataStore) Stat() *pgxpool.Stat {
+	return d.pool.Stat()
+}
+
+func (d *DataStore) Ping(ctx context.Context) error {
+	if err := d.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const tokenBucketResultLen = 2
+
+var errUnexpectedScriptResult = errors.New("unexpected token bucket script result")
+
+// takeTokenScript refills the bucket by the time passed since the last call and
+// takes one token when there is one. Redis time is used so that all instances
+// share the same clock. Returns whether the token was taken and the tokens left.
+//
+//nolint:gochecknoglobals
+var takeTokenScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(bucket[1])
+local ts = tonumber(bucket[2])
+
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000) + 1000)
+
+return {allowed, tostring(tokens)}
+`)
+
+// TakeToken takes a token from the bucket stored at key. The bucket holds up to
+// burst tokens and is refilled with rate tokens per second.
+func (c *Client) TakeToken(ctx context.Context, key string, rate float64, burst int) (bool, float64, error) {
+	if !c.available.Load() {
+		return false, 0, ErrUnavailable
+	}
+
+	result, err := takeTokenScript.Run(ctx, c.client, []string{key}, rate, burst).Slice()
+	if err != nil {
+		c.checkResult(err)
+
+		return false, 0, fmt.Errorf("failed to run token bucket script: %w", err)
+	}
+
+	if len(result) != tokenBucketResultLen {
+		return false, 0, fmt.Errorf("%w: %v", errUnexpectedScriptResult, result)
+	}
+
+	allowed, _ := result[0].(int64)
+	remainingStr, _ := result[1].(string)
+
+	remaining, err := strconv.ParseFloat(remainingStr, 64)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to parse remaining tokens: %w", err)
+	}
+
+	return allowed == 1, remaining, nil
+}
@@ -2,37 +2,101 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog/log"
 )
 
+const scanBatchSize = 100
+
+var ErrUnavailable = errors.New("redis is unavailable")
+
+// Client fails fast while Redis is unreachable instead of waiting for a
+// connection on every command. Monitor brings it back once Redis answers.
 type Client struct {
-	client *redis.Client
+	client    *redis.Client
+	available atomic.Bool
 }
 
-func New(ctx context.Context, addr, password string, db int) (*Client, error) {
+// Connect creates a client without waiting for Redis to be reachable.
+func Connect(addr, password string, db int) *Client {
 	redisDB := redis.NewClient(&redis.Options{
 		Addr:     addr,
 		Password: password,
 		DB:       db,
 	})
 
-	_, err := redisDB.Ping(ctx).Result()
-	if err != nil {
+	redisDB.AddHook(tracingHook{})
+
+	return &Client{client: redisDB}
+}
+
+// New creates a client and fails if Redis is unreachable.
+func New(ctx context.Context, addr, password string, db int) (*Client, error) {
+	client := Connect(addr, password, db)
+
+	if err := client.Ping(ctx); err != nil {
 		return nil, fmt.Errorf("error connecting to Redis: %w", err)
 	}
 
 	log.Info().Msg("connected to Redis")
 
-	return &Client{client: redisDB}, nil
+	return client, nil
+}
+
+// Monitor pings Redis every interval until ctx is done.
+func (c *Client) Monitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		wasAvailable := c.available.Load()
+
+		err := c.Ping(ctx)
+
+		switch {
+		case err == nil && !wasAvailable:
+			log.Info().Msg("connected to Redis")
+		case err != nil && wasAvailable:
+			log.Warn().Err(err).Msg("lost connection to Redis, working without cache")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Client) Available() bool {
+	return c.available.Load()
+}
+
+// checkResult marks the client unavailable when err is a connection failure
+// rather than a missing key or an error reply from Redis.
+func (c *Client) checkResult(err error) {
+	var replyErr redis.Error
+
+	if err != nil && !errors.Is(err, redis.Nil) && !errors.As(err, &replyErr) &&
+		!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+		c.available.Store(false)
+	}
 }
 
 func (c *Client) Get(ctx context.Context, key string) (string, error) {
+	if !c.available.Load() {
+		return "", ErrUnavailable
+	}
+
 	val, err := c.client.Get(ctx, key).Result()
 	if err != nil {
+		c.checkResult(err)
+
 		return "", fmt.Errorf("redis get failed: %w", err)
 	}
 
@@ -40,7 +104,13 @@ func (c *Client) Get(ctx context.Context, key string) (string, error) {
 }
 
 func (c *Client) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	if !c.available.Load() {
+		return ErrUnavailable
+	}
+
 	if err := c.client.Set(ctx, key, value, expiration).Err(); err != nil {
+		c.checkResult(err)
+
 		return fmt.Errorf("error in Set(): %w", err)
 	}
 
@@ -48,13 +118,66 @@ func (c *Client) Set(ctx context.Context, key string, value interface{}, expirat
 }
 
 func (c *Client) Del(ctx context.Context, keys ...string) error {
+	if !c.available.Load() {
+		return ErrUnavailable
+	}
+
 	if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		c.checkResult(err)
+
 		return fmt.Errorf("error deleting good: %w", err)
 	}
 
 	return nil
 }
 
+// DelPattern deletes the keys matching pattern. Keys are scanned in batches, so
+// it doesn't block Redis on large databases.
+func (c *Client) DelPattern(ctx context.Context, pattern string) error {
+	if !c.available.Load() {
+		return ErrUnavailable
+	}
+
+	iter := c.client.Scan(ctx, 0, pattern, scanBatchSize).Iterator()
+	keys := make([]string, 0, scanBatchSize)
+
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+
+		if len(keys) == scanBatchSize {
+			if err := c.Del(ctx, keys...); err != nil {
+				return err
+			}
+
+			keys = keys[:0]
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		c.checkResult(err)
+
+		return fmt.Errorf("error scanning keys: %w", err)
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return c.Del(ctx, keys...)
+}
+
+func (c *Client) Ping(ctx context.Context) error {
+	if err := c.client.Ping(ctx).Err(); err != nil {
+		c.available.Store(false)
+
+		return fmt.Errorf("redis ping failed: %w", err)
+	}
+
+	c.available.Store(true)
+
+	return nil
+}
+
 func (c *Client) Close() error {
 	if err := c.client.Close(); err != nil {
 		return fmt.Errorf("error closing redis client: %w", err)
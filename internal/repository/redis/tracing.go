@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/romanpitatelev/hezzl-goods/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingHook starts a span for every command sent to Redis.
+type tracingHook struct{}
+
+func (tracingHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (tracingHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := tracing.Start(ctx, "redis "+cmd.Name(),
+			trace.WithSpanKind(trace.SpanKindClient),
+			tracing.DBAttributes("redis", cmd.Name()),
+		)
+
+		err := next(ctx, cmd)
+
+		// A missing key is a cache miss, not a failure.
+		if errors.Is(err, redis.Nil) {
+			tracing.End(span, nil)
+		} else {
+			tracing.End(span, err)
+		}
+
+		return err
+	}
+}
+
+func (tracingHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		ctx, span := tracing.Start(ctx, "redis pipeline",
+			trace.WithSpanKind(trace.SpanKindClient),
+			tracing.DBAttributes("redis", "pipeline"),
+		)
+
+		err := next(ctx, cmds)
+		tracing.End(span, err)
+
+		return err
+	}
+}
@@ -0,0 +1,276 @@
+package webhooksrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+)
+
+const deliveriesLimit = 100
+
+type Repo struct {
+	db *postgres.DataStore
+}
+
+func New(db *postgres.DataStore) *Repo {
+	return &Repo{
+		db: db,
+	}
+}
+
+func (r *Repo) CreateWebhook(ctx context.Context, projectID int, req entity.WebhookCreateRequest) (entity.Webhook, error) {
+	var webhook entity.Webhook
+
+	query := `
+INSERT INTO webhooks (project_id, url, secret)
+VALUES ($1, $2, $3)
+RETURNING id, project_id, url, secret, active, failure_count, created_at, disabled_at
+`
+	row := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, projectID, req.URL, req.Secret)
+
+	if err := row.Scan(
+		&webhook.ID,
+		&webhook.ProjectID,
+		&webhook.URL,
+		&webhook.Secret,
+		&webhook.Active,
+		&webhook.FailureCount,
+		&webhook.CreatedAt,
+		&webhook.DisabledAt,
+	); err != nil {
+		return entity.Webhook{}, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *Repo) GetWebhooks(ctx context.Context, projectID int) ([]entity.Webhook, error) {
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, `
+SELECT id, project_id, url, active, failure_count, created_at, disabled_at
+FROM webhooks
+WHERE project_id = $1
+ORDER BY id`,
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while querying in GetWebhooks(): %w", err)
+	}
+
+	defer rows.Close()
+
+	webhooks := make([]entity.Webhook, 0)
+
+	for rows.Next() {
+		var webhook entity.Webhook
+		if err := rows.Scan(
+			&webhook.ID,
+			&webhook.ProjectID,
+			&webhook.URL,
+			&webhook.Active,
+			&webhook.FailureCount,
+			&webhook.CreatedAt,
+			&webhook.DisabledAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning webhook: %w", err)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *Repo) GetActiveWebhooks(ctx context.Context, projectID int) ([]entity.Webhook, error) {
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, `
+SELECT id, project_id, url, secret, active, failure_count, created_at, disabled_at
+FROM webhooks
+WHERE project_id = $1 AND active = true`,
+		projectID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while querying in GetActiveWebhooks(): %w", err)
+	}
+
+	defer rows.Close()
+
+	var webhooks []entity.Webhook
+
+	for rows.Next() {
+		var webhook entity.Webhook
+		if err := rows.Scan(
+			&webhook.ID,
+			&webhook.ProjectID,
+			&webhook.URL,
+			&webhook.Secret,
+			&webhook.Active,
+			&webhook.FailureCount,
+			&webhook.CreatedAt,
+			&webhook.DisabledAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning webhook: %w", err)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating active webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *Repo) DeleteWebhook(ctx context.Context, id int, projectID int) error {
+	cmdTag, err := r.db.GetTXFromContext(ctx).Exec(ctx,
+		`DELETE FROM webhooks WHERE id = $1 AND project_id = $2`,
+		id, projectID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return entity.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *Repo) EnableWebhook(ctx context.Context, id int, projectID int) (entity.Webhook, error) {
+	var webhook entity.Webhook
+
+	query := `
+UPDATE webhooks
+SET active = true,
+	failure_count = 0,
+	disabled_at = NULL
+WHERE id = $1 AND project_id = $2
+RETURNING id, project_id, url, active, failure_count, created_at, disabled_at
+`
+	row := r.db.GetTXFromContext(ctx).QueryRow(ctx, query, id, projectID)
+
+	if err := row.Scan(
+		&webhook.ID,
+		&webhook.ProjectID,
+		&webhook.URL,
+		&webhook.Active,
+		&webhook.FailureCount,
+		&webhook.CreatedAt,
+		&webhook.DisabledAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Webhook{}, entity.ErrWebhookNotFound
+		}
+
+		return entity.Webhook{}, fmt.Errorf("failed to scan enabled webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (r *Repo) RecordDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	_, err := r.db.GetTXFromContext(ctx).Exec(ctx, `
+INSERT INTO webhook_deliveries (webhook_id, operation, good_id, attempt, status_code, error, success)
+VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		delivery.WebhookID,
+		delivery.Operation,
+		delivery.GoodID,
+		delivery.Attempt,
+		delivery.StatusCode,
+		delivery.Error,
+		delivery.Success,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repo) MarkDelivered(ctx context.Context, id int) error {
+	if _, err := r.db.GetTXFromContext(ctx).Exec(ctx,
+		`UPDATE webhooks SET failure_count = 0 WHERE id = $1`, id,
+	); err != nil {
+		return fmt.Errorf("failed to reset webhook failures: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed counts a failed event delivery and disables the webhook once
+// failure_count reaches disableThreshold. It reports whether the webhook was disabled.
+func (r *Repo) MarkFailed(ctx context.Context, id int, disableThreshold int) (bool, error) {
+	var active bool
+
+	row := r.db.GetTXFromContext(ctx).QueryRow(ctx, `
+UPDATE webhooks
+SET failure_count = failure_count + 1,
+	active = failure_count + 1 < $2,
+	disabled_at = CASE WHEN failure_count + 1 >= $2 THEN CURRENT_TIMESTAMP ELSE disabled_at END
+WHERE id = $1 AND active = true
+RETURNING active`,
+		id, disableThreshold,
+	)
+
+	if err := row.Scan(&active); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to count webhook failure: %w", err)
+	}
+
+	return !active, nil
+}
+
+func (r *Repo) GetDeliveries(ctx context.Context, webhookID int, projectID int) ([]entity.WebhookDelivery, error) {
+	rows, err := r.db.GetTXFromContext(ctx).Query(ctx, `
+SELECT d.id, d.webhook_id, d.operation, d.good_id, d.attempt, d.status_code, d.error, d.success, d.created_at
+FROM webhook_deliveries d
+JOIN webhooks w ON w.id = d.webhook_id
+WHERE d.webhook_id = $1 AND w.project_id = $2
+ORDER BY d.id DESC
+LIMIT $3`,
+		webhookID, projectID, deliveriesLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while querying in GetDeliveries(): %w", err)
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.Operation,
+			&delivery.GoodID,
+			&delivery.Attempt,
+			&delivery.StatusCode,
+			&delivery.Error,
+			&delivery.Success,
+			&delivery.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning webhook delivery: %w", err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
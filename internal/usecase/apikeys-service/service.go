@@ -0,0 +1,165 @@
+package apikeysservice
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+const (
+	keyPrefix    = "hzl_"
+	keyLength    = 32
+	prefixLength = len(keyPrefix) + 8
+
+	bootstrapPrincipalID = "bootstrap-admin"
+)
+
+type apiKeysStore interface {
+	CreateAPIKey(ctx context.Context, req entity.APIKeyCreateRequest, prefix, hash string) (entity.APIKey, error)
+	GetAPIKeyByHash(ctx context.Context, hash string) (entity.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]entity.APIKey, error)
+	RotateAPIKey(ctx context.Context, id int, prefix, hash string) (entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id int) error
+}
+
+type Service struct {
+	apiKeysStore  apiKeysStore
+	bootstrapHash []byte
+}
+
+// New creates the service. A non-empty bootstrapKey is accepted as an admin key
+// for all projects so that the first keys can be issued.
+func New(apiKeysStore apiKeysStore, bootstrapKey string) *Service {
+	s := &Service{
+		apiKeysStore: apiKeysStore,
+	}
+
+	if bootstrapKey != "" {
+		hash := sha256.Sum256([]byte(bootstrapKey))
+		s.bootstrapHash = hash[:]
+	}
+
+	return s
+}
+
+func (s *Service) IssueAPIKey(ctx context.Context, req entity.APIKeyCreateRequest) (entity.APIKey, error) {
+	if err := req.Validate(); err != nil {
+		return entity.APIKey{}, fmt.Errorf("failed to validate api key: %w", err)
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	apiKey, err := s.apiKeysStore.CreateAPIKey(ctx, req, key[:prefixLength], hashKey(key))
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("failed to issue api key: %w", err)
+	}
+
+	apiKey.Key = key
+
+	return apiKey, nil
+}
+
+func (s *Service) GetAPIKeys(ctx context.Context) (entity.APIKeysListResponse, error) {
+	apiKeys, err := s.apiKeysStore.GetAPIKeys(ctx)
+	if err != nil {
+		return entity.APIKeysListResponse{}, fmt.Errorf("failed to get api keys: %w", err)
+	}
+
+	return entity.APIKeysListResponse{
+		APIKeys: apiKeys,
+	}, nil
+}
+
+func (s *Service) RotateAPIKey(ctx context.Context, id int) (entity.APIKey, error) {
+	if id <= 0 {
+		return entity.APIKey{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	apiKey, err := s.apiKeysStore.RotateAPIKey(ctx, id, key[:prefixLength], hashKey(key))
+	if err != nil {
+		return entity.APIKey{}, fmt.Errorf("failed to rotate api key: %w", err)
+	}
+
+	apiKey.Key = key
+
+	return apiKey, nil
+}
+
+func (s *Service) RevokeAPIKey(ctx context.Context, id int) error {
+	if id <= 0 {
+		return entity.ErrInvalidIDOrProjectID
+	}
+
+	if err := s.apiKeysStore.RevokeAPIKey(ctx, id); err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) Authenticate(ctx context.Context, key string) (entity.Principal, error) {
+	if key == "" {
+		return entity.Principal{}, entity.ErrUnauthorized
+	}
+
+	hash := hashKey(key)
+
+	if s.bootstrapHash != nil {
+		decoded, err := hex.DecodeString(hash)
+		if err == nil && subtle.ConstantTimeCompare(decoded, s.bootstrapHash) == 1 {
+			return entity.Principal{
+				ID:          bootstrapPrincipalID,
+				Scopes:      []string{entity.ScopeAdmin},
+				AllProjects: true,
+			}, nil
+		}
+	}
+
+	apiKey, err := s.apiKeysStore.GetAPIKeyByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, entity.ErrAPIKeyNotFound) {
+			return entity.Principal{}, entity.ErrUnauthorized
+		}
+
+		return entity.Principal{}, fmt.Errorf("failed to authenticate api key: %w", err)
+	}
+
+	principal := entity.Principal{
+		ID:         "apikey:" + strconv.Itoa(apiKey.ID),
+		ProjectIDs: apiKey.ProjectIDs,
+		Scopes:     apiKey.Scopes,
+	}
+	principal.AllProjects = principal.HasScope(entity.ScopeAdmin)
+
+	return principal, nil
+}
+
+func generateKey() (string, error) {
+	key := make([]byte, keyLength)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	return keyPrefix + hex.EncodeToString(key), nil
+}
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,186 @@
+package goodsexport
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+type goodsStore interface {
+	ExportGoods(ctx context.Context, request entity.ListRequest, fn func(entity.Good) error) error
+	GetAttributeSchema(ctx context.Context, projectID int) (entity.AttributeSchema, error)
+}
+
+var csvHeader = []string{
+	"id", "project_id", "external_id", "name", "description", "priority", "removed", "tags", "category_id", "attributes", "created_at",
+}
+
+type Exporter struct {
+	goodsStore goodsStore
+}
+
+func New(goodsStore goodsStore) *Exporter {
+	return &Exporter{
+		goodsStore: goodsStore,
+	}
+}
+
+// Export writes the goods of the request to w in priority order and returns
+// their number. An invalid request is reported before anything is written.
+func (e *Exporter) Export(ctx context.Context, request entity.ExportRequest, w io.Writer) (int, error) {
+	if err := request.Validate(); err != nil {
+		return 0, fmt.Errorf("failed to validate export: %w", err)
+	}
+
+	if len(request.Filter.Attributes) > 0 {
+		schema, err := e.goodsStore.GetAttributeSchema(ctx, request.Filter.ProjectID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get attribute schema: %w", err)
+		}
+
+		if err := schema.ValidateFilters(request.Filter.Attributes); err != nil {
+			return 0, fmt.Errorf("failed to validate attribute filters: %w", err)
+		}
+	}
+
+	var writer goodsWriter
+
+	switch request.Format {
+	case entity.FormatJSONL:
+		writer = newJSONLWriter(w)
+	default:
+		writer = newCSVWriter(w)
+	}
+
+	exported := 0
+
+	err := e.goodsStore.ExportGoods(ctx, request.Filter, func(good entity.Good) error {
+		exported++
+
+		return writer.write(good)
+	})
+	if err != nil {
+		return exported, fmt.Errorf("failed to export goods: %w", err)
+	}
+
+	if err := writer.flush(); err != nil {
+		return exported, fmt.Errorf("failed to write goods: %w", err)
+	}
+
+	return exported, nil
+}
+
+type goodsWriter interface {
+	write(good entity.Good) error
+	flush() error
+}
+
+// csvWriter writes a header and a row per good. Tags are separated by
+// commas, attributes are a JSON object.
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) write(good entity.Good) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	attributes, err := json.Marshal(good.Attributes)
+	if err != nil {
+		return fmt.Errorf("failed to marshal attributes: %w", err)
+	}
+
+	categoryID := ""
+	if good.CategoryID != nil {
+		categoryID = strconv.Itoa(*good.CategoryID)
+	}
+
+	externalID := ""
+	if good.ExternalID != nil {
+		externalID = *good.ExternalID
+	}
+
+	if err := c.w.Write([]string{
+		strconv.Itoa(good.ID),
+		strconv.Itoa(good.ProjectID),
+		externalID,
+		good.Name,
+		good.Description,
+		strconv.Itoa(good.Priority),
+		strconv.FormatBool(good.Removed),
+		strings.Join(good.Tags, ","),
+		categoryID,
+		string(attributes),
+		good.CreatedAt.Format(time.RFC3339),
+	}); err != nil {
+		return fmt.Errorf("failed to write csv row: %w", err)
+	}
+
+	return nil
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+
+	c.headerWritten = true
+
+	if err := c.w.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	return nil
+}
+
+// flush writes the header even if there are no goods.
+func (c *csvWriter) flush() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	c.w.Flush()
+
+	return c.w.Error() //nolint:wrapcheck
+}
+
+// jsonlWriter writes a JSON object per good and line.
+type jsonlWriter struct {
+	buf *bufio.Writer
+	enc *json.Encoder
+}
+
+func newJSONLWriter(w io.Writer) *jsonlWriter {
+	buf := bufio.NewWriter(w)
+
+	return &jsonlWriter{
+		buf: buf,
+		enc: json.NewEncoder(buf),
+	}
+}
+
+func (j *jsonlWriter) write(good entity.Good) error {
+	if err := j.enc.Encode(good); err != nil {
+		return fmt.Errorf("failed to write good: %w", err)
+	}
+
+	return nil
+}
+
+func (j *jsonlWriter) flush() error {
+	return j.buf.Flush() //nolint:wrapcheck
+}
@@ -0,0 +1,79 @@
+package goodsservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *Service) GetAttributeSchema(ctx context.Context, projectID int) (entity.AttributeSchema, error) {
+	if projectID <= 0 {
+		return entity.AttributeSchema{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	schema, err := s.goodsStore.GetAttributeSchema(ctx, projectID)
+	if err != nil {
+		return entity.AttributeSchema{}, fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+
+	return schema, nil
+}
+
+// SetAttributeSchema replaces the attribute schema of the project. Goods
+// are checked against the schema when they are created or their attributes
+// are updated, existing attributes are left as they are.
+func (s *Service) SetAttributeSchema(
+	ctx context.Context, projectID int, req entity.AttributeSchemaRequest,
+) (entity.AttributeSchema, error) {
+	if projectID <= 0 {
+		return entity.AttributeSchema{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	if err := req.Validate(); err != nil {
+		return entity.AttributeSchema{}, fmt.Errorf("failed to validate attribute schema: %w", err)
+	}
+
+	schema, err := s.goodsStore.SetAttributeSchema(ctx, projectID, req.Attributes)
+	if err != nil {
+		return entity.AttributeSchema{}, fmt.Errorf("failed to set attribute schema: %w", err)
+	}
+
+	return schema, nil
+}
+
+func (s *Service) validateAttributes(ctx context.Context, projectID int, attributes map[string]any) error {
+	schema, err := s.goodsStore.GetAttributeSchema(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+
+	if err := schema.ValidateAttributes(attributes); err != nil {
+		return fmt.Errorf("failed to validate good: %w", err)
+	}
+
+	return nil
+}
+
+// validateAttributeFilters types the attribute filters of the list by the
+// schema of its project and sorts them for the cache key.
+func (s *Service) validateAttributeFilters(ctx context.Context, request *entity.ListRequest) error {
+	if len(request.Attributes) == 0 {
+		return nil
+	}
+
+	if request.ProjectID == 0 {
+		return fmt.Errorf("%w: attributes can only be filtered in a project", entity.ErrInvalidAttributeFilter)
+	}
+
+	schema, err := s.goodsStore.GetAttributeSchema(ctx, request.ProjectID)
+	if err != nil {
+		return fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+
+	if err := schema.ValidateFilters(request.Attributes); err != nil {
+		return fmt.Errorf("failed to validate attribute filters: %w", err)
+	}
+
+	return nil
+}
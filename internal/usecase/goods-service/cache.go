@@ -0,0 +1,160 @@
+package goodsservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+// Key classes of the cache.
+const (
+	cacheGood = "good"
+	cacheList = "list"
+)
+
+// notFoundValue is cached for goods that don't exist.
+const notFoundValue = "not-found"
+
+// CacheConfig is the policy of caching goods in Redis.
+type CacheConfig struct {
+	GoodTTL time.Duration
+	ListTTL time.Duration
+	// NotFoundTTL is how long a missing good is remembered. Zero disables negative caching.
+	NotFoundTTL time.Duration
+	// Jitter shortens every TTL by a random share up to Jitter, so that keys
+	// cached together don't expire together.
+	Jitter float64
+	// MaxValueSize is the size in bytes of the largest cached value. Zero means no limit.
+	MaxValueSize int
+}
+
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		GoodTTL:      time.Minute,
+		ListTTL:      time.Minute,
+		NotFoundTTL:  10 * time.Second,
+		Jitter:       0.1,
+		MaxValueSize: 1 << 20,
+	}
+}
+
+func goodCacheKey(id int, projectID int) string {
+	return fmt.Sprintf("good:%d:%d", id, projectID)
+}
+
+func listCacheKey(request entity.ListRequest) string {
+	key := fmt.Sprintf("%s%d:%d", listCachePrefix(request.ProjectID), request.Limit, request.Offset)
+
+	if request.CategoryID > 0 {
+		key += fmt.Sprintf(":category:%d", request.CategoryID)
+	}
+
+	if len(request.Tags) > 0 {
+		key += fmt.Sprintf(":tags:%s:%s", request.TagMode, strings.Join(request.Tags, ","))
+	}
+
+	for _, filter := range request.Attributes {
+		key += ":attr:" + filter.String()
+	}
+
+	return key
+}
+
+func listCachePrefix(projectID int) string {
+	return fmt.Sprintf("goods:list:%d:", projectID)
+}
+
+// fromCache reads the value at key into dst and reports whether it was cached.
+// A good cached as missing is returned as ErrGoodNotFound.
+func (s *Service) fromCache(ctx context.Context, class, key string, dst any) (bool, error) {
+	if entity.CacheBypassFromContext(ctx) {
+		metrics.CacheRequests.WithLabelValues(class, metrics.CacheBypass).Inc()
+
+		return false, nil
+	}
+
+	cached, err := s.redisClient.Get(ctx, key)
+	if err != nil || cached == "" {
+		metrics.CacheRequests.WithLabelValues(class, metrics.CacheMiss).Inc()
+
+		return false, nil
+	}
+
+	if cached == notFoundValue {
+		metrics.CacheRequests.WithLabelValues(class, metrics.CacheHit).Inc()
+
+		return true, entity.ErrGoodNotFound
+	}
+
+	if err := json.Unmarshal([]byte(cached), dst); err != nil {
+		log.Warn().Err(err).Msgf("failed to unmarshal cached %s", class)
+		metrics.CacheRequests.WithLabelValues(class, metrics.CacheMiss).Inc()
+
+		return false, nil
+	}
+
+	metrics.CacheRequests.WithLabelValues(class, metrics.CacheHit).Inc()
+
+	return true, nil
+}
+
+func (s *Service) toCache(ctx context.Context, class, key string, value any, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Warn().Err(err).Msgf("failed to marshal %s for caching", class)
+
+		return
+	}
+
+	s.setCache(ctx, class, key, data, ttl)
+}
+
+func (s *Service) setCache(ctx context.Context, class, key string, data []byte, ttl time.Duration) {
+	cfg := s.cacheConfig.Load()
+
+	if ttl <= 0 {
+		return
+	}
+
+	if cfg.MaxValueSize > 0 && len(data) > cfg.MaxValueSize {
+		log.Debug().Msgf("%s of %d bytes is too large to cache", class, len(data))
+
+		return
+	}
+
+	if cfg.Jitter > 0 {
+		ttl -= time.Duration(rand.Float64() * cfg.Jitter * float64(ttl)) //nolint:gosec
+	}
+
+	if err := s.redisClient.Set(ctx, key, data, ttl); err != nil {
+		log.Warn().Err(err).Msgf("failed to cache %s", class)
+	}
+}
+
+// invalidate removes the goods, all lists of the project and the lists of all
+// projects from the cache.
+func (s *Service) invalidate(ctx context.Context, projectID int, ids ...int) {
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, goodCacheKey(id, projectID))
+	}
+
+	if len(keys) > 0 {
+		if err := s.redisClient.Del(ctx, keys...); err != nil {
+			log.Warn().Err(err).Msg("failed to invalidate cached goods")
+		}
+	}
+
+	for _, id := range []int{projectID, 0} {
+		if err := s.redisClient.DelPattern(ctx, listCachePrefix(id)+"*"); err != nil {
+			log.Warn().Err(err).Msg("failed to invalidate cached goods lists")
+		}
+	}
+}
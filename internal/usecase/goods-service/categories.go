@@ -0,0 +1,135 @@
+package goodsservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+func (s *Service) CreateCategory(ctx context.Context, projectID int, req entity.CategoryCreateRequest) (entity.Category, error) {
+	if projectID <= 0 {
+		return entity.Category{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	if err := req.Validate(); err != nil {
+		return entity.Category{}, fmt.Errorf("failed to validate category: %w", err)
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+
+	category, err := s.goodsStore.CreateCategory(ctx, projectID, req)
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return category, nil
+}
+
+func (s *Service) GetCategories(ctx context.Context, projectID int) (entity.CategoriesResponse, error) {
+	if projectID <= 0 {
+		return entity.CategoriesResponse{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	categories, err := s.goodsStore.GetCategories(ctx, projectID)
+	if err != nil {
+		return entity.CategoriesResponse{}, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	return entity.CategoriesResponse{Categories: categories}, nil
+}
+
+func (s *Service) RenameCategory(
+	ctx context.Context, id int, projectID int, req entity.CategoryRenameRequest,
+) (entity.Category, error) {
+	if id <= 0 || projectID <= 0 {
+		return entity.Category{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return entity.Category{}, entity.ErrEmptyCategoryName
+	}
+
+	category, err := s.goodsStore.RenameCategory(ctx, id, projectID, name)
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to rename category: %w", err)
+	}
+
+	return category, nil
+}
+
+// MoveCategory moves the category with its subtree, which changes the goods listed under its old and new ancestors.
+func (s *Service) MoveCategory(
+	ctx context.Context, id int, projectID int, req entity.CategoryMoveRequest,
+) (entity.Category, error) {
+	if id <= 0 || projectID <= 0 || (req.ParentID != nil && *req.ParentID <= 0) {
+		return entity.Category{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	category, err := s.goodsStore.MoveCategory(ctx, id, projectID, req.ParentID)
+	if err != nil {
+		return entity.Category{}, fmt.Errorf("failed to move category: %w", err)
+	}
+
+	s.invalidate(ctx, projectID)
+
+	return category, nil
+}
+
+// DeleteCategory deletes the category with its subtree. Their goods are moved to the parent of the category.
+func (s *Service) DeleteCategory(ctx context.Context, id int, projectID int) (entity.CategoryDeleteResponse, error) {
+	if id <= 0 || projectID <= 0 {
+		return entity.CategoryDeleteResponse{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	deleted, goods, err := s.goodsStore.DeleteCategory(ctx, id, projectID)
+	if err != nil {
+		return entity.CategoryDeleteResponse{}, fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	s.changed(ctx, projectID, goods)
+
+	return entity.CategoryDeleteResponse{Deleted: deleted, MovedGoods: len(goods)}, nil
+}
+
+func (s *Service) SetGoodCategory(
+	ctx context.Context, id int, projectID int, req entity.GoodCategoryRequest,
+) (entity.Good, error) {
+	if id <= 0 || projectID <= 0 || (req.CategoryID != nil && *req.CategoryID <= 0) {
+		return entity.Good{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	good, previous, err := s.goodsStore.SetGoodCategory(ctx, id, projectID, req.CategoryID)
+	if err != nil {
+		return entity.Good{}, fmt.Errorf("failed to set category of good: %w", err)
+	}
+
+	s.invalidate(ctx, projectID, id)
+
+	logMsg := entity.GoodLog{
+		Operation:      "update",
+		GoodID:         good.ID,
+		ProjectID:      good.ProjectID,
+		ExternalID:     good.ExternalID,
+		Name:           good.Name,
+		Description:    good.Description,
+		Priority:       good.Priority,
+		Removed:        good.Removed,
+		Tags:           good.Tags,
+		CategoryID:     good.CategoryID,
+		Attributes:     good.Attributes,
+		PrevPriority:   &previous.Priority,
+		PrevCategoryID: previous.CategoryID,
+		EventTime:      time.Now(),
+	}
+
+	if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
+		log.Warn().Err(err).Msg("failed to publish good category to NATS")
+	}
+
+	return good, nil
+}
@@ -0,0 +1,109 @@
+package goodsservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+func (s *Service) GetGoodByExternalID(ctx context.Context, externalID string, projectID int) (entity.Good, error) {
+	if projectID <= 0 {
+		return entity.Good{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	externalID, err := entity.NormalizeExternalID(externalID)
+	if err != nil {
+		return entity.Good{}, fmt.Errorf("failed to validate external id: %w", err)
+	}
+
+	good, err := s.goodsStore.GetGoodByExternalID(ctx, externalID, projectID)
+	if err != nil {
+		return entity.Good{}, fmt.Errorf("failed to get good: %w", err)
+	}
+
+	logMsg := entity.GoodLog{
+		Operation:   "get",
+		GoodID:      good.ID,
+		ProjectID:   good.ProjectID,
+		ExternalID:  good.ExternalID,
+		Name:        good.Name,
+		Description: good.Description,
+		Priority:    good.Priority,
+		Removed:     good.Removed,
+		Tags:        good.Tags,
+		CategoryID:  good.CategoryID,
+		Attributes:  good.Attributes,
+		EventTime:   time.Now(),
+	}
+
+	if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
+		log.Warn().Err(err).Msg("failed to publish get good to NATS")
+	}
+
+	return good, nil
+}
+
+// UpsertGood creates the good of the external id of the request or replaces
+// the good that has it. Reports whether the good was created.
+func (s *Service) UpsertGood(ctx context.Context, projectID int, req entity.GoodCreateRequest) (entity.Good, bool, error) {
+	if projectID <= 0 {
+		return entity.Good{}, false, entity.ErrInvalidIDOrProjectID
+	}
+
+	if req.ExternalID == nil {
+		return entity.Good{}, false, entity.ErrExternalIDRequired
+	}
+
+	if err := req.Validate(); err != nil {
+		return entity.Good{}, false, fmt.Errorf("failed to validate good: %w", err)
+	}
+
+	if err := s.validateAttributes(ctx, projectID, req.Attributes); err != nil {
+		return entity.Good{}, false, err
+	}
+
+	good, previous, created, err := s.goodsStore.UpsertGood(ctx, projectID, req)
+	if err != nil {
+		return entity.Good{}, false, fmt.Errorf("failed to upsert good: %w", err)
+	}
+
+	s.invalidate(ctx, projectID, good.ID)
+
+	logMsg := entity.GoodLog{
+		Operation:   "create",
+		GoodID:      good.ID,
+		ProjectID:   good.ProjectID,
+		ExternalID:  good.ExternalID,
+		Name:        good.Name,
+		Description: good.Description,
+		Priority:    good.Priority,
+		Removed:     good.Removed,
+		Tags:        good.Tags,
+		CategoryID:  good.CategoryID,
+		Attributes:  good.Attributes,
+		EventTime:   time.Now(),
+	}
+
+	if !created {
+		logMsg.Operation = "update"
+
+		// The previous good is unknown if it was created concurrently.
+		if previous.ID != 0 {
+			logMsg.PrevName = &previous.Name
+			logMsg.PrevDescription = &previous.Description
+			logMsg.PrevPriority = &previous.Priority
+			logMsg.PrevTags = previous.Tags
+			logMsg.PrevCategoryID = previous.CategoryID
+			logMsg.PrevAttributes = previous.Attributes
+		}
+	}
+
+	if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
+		log.Warn().Err(err).Msg("failed to publish upserted good to NATS")
+	}
+
+	return good, created, nil
+}
@@ -0,0 +1,339 @@
+package goodsservice
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/rs/zerolog/log"
+)
+
+const maxImportLineSize = 1 << 20
+
+// ImportGoods reads the goods of a CSV or JSON Lines file and applies the
+// valid rows in one transaction. Every row is validated like a created good,
+// the invalid ones are reported by line. Applied imports publish a log per
+// good and a summary.
+func (s *Service) ImportGoods(ctx context.Context, request entity.ImportRequest, r io.Reader) (entity.ImportResponse, error) {
+	if err := request.Validate(); err != nil {
+		return entity.ImportResponse{}, fmt.Errorf("failed to validate import: %w", err)
+	}
+
+	rows, errs, err := readImportRows(request.Format, r)
+	if err != nil {
+		return entity.ImportResponse{}, fmt.Errorf("failed to read import: %w", err)
+	}
+
+	total := len(rows) + len(errs)
+
+	schema, err := s.goodsStore.GetAttributeSchema(ctx, request.ProjectID)
+	if err != nil {
+		return entity.ImportResponse{}, fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+
+	valid := make([]entity.ImportRow, 0, len(rows))
+
+	for _, row := range rows {
+		if err := row.Validate(request); err != nil {
+			errs = append(errs, entity.ImportError{Line: row.Line, Error: err.Error()})
+
+			continue
+		}
+
+		if err := schema.ValidateAttributes(row.Attributes); err != nil {
+			errs = append(errs, entity.ImportError{Line: row.Line, Error: err.Error()})
+
+			continue
+		}
+
+		valid = append(valid, row)
+	}
+
+	result, err := s.goodsStore.ImportGoods(ctx, request, valid)
+	if err != nil {
+		return entity.ImportResponse{}, fmt.Errorf("failed to import goods: %w", err)
+	}
+
+	errs = append(errs, result.Errors...)
+	slices.SortFunc(errs, func(a, b entity.ImportError) int { return a.Line - b.Line })
+
+	response := entity.ImportResponse{
+		DryRun:  request.DryRun,
+		Total:   total,
+		Created: len(result.Created),
+		Updated: len(result.Updated),
+		Failed:  len(errs),
+		Errors:  errs,
+	}
+
+	if !request.DryRun {
+		s.imported(ctx, request, result, response)
+	}
+
+	return response, nil
+}
+
+// imported invalidates the cache of the imported goods and publishes their
+// logs and the summary of the import.
+func (s *Service) imported(ctx context.Context, request entity.ImportRequest, result entity.ImportResult, response entity.ImportResponse) {
+	ids := make([]int, 0, len(result.Created)+len(result.Updated))
+	for _, good := range result.Created {
+		ids = append(ids, good.ID)
+	}
+
+	for _, good := range result.Updated {
+		ids = append(ids, good.ID)
+	}
+
+	s.invalidate(ctx, request.ProjectID, ids...)
+
+	for _, good := range result.Created {
+		logMsg := entity.GoodLog{
+			Operation:   "create",
+			GoodID:      good.ID,
+			ProjectID:   good.ProjectID,
+			ExternalID:  good.ExternalID,
+			Name:        good.Name,
+			Description: good.Description,
+			Priority:    good.Priority,
+			Removed:     good.Removed,
+			Tags:        good.Tags,
+			CategoryID:  good.CategoryID,
+			Attributes:  good.Attributes,
+			EventTime:   time.Now(),
+		}
+
+		if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
+			log.Warn().Err(err).Msgf("failed to publish imported good %d to NATS", good.ID)
+		}
+	}
+
+	for _, good := range result.Updated {
+		logMsg := entity.GoodLog{
+			Operation:       "update",
+			GoodID:          good.ID,
+			ProjectID:       good.ProjectID,
+			ExternalID:      good.ExternalID,
+			Name:            good.Name,
+			Description:     good.Description,
+			Priority:        good.Priority,
+			Removed:         good.Removed,
+			Tags:            good.Tags,
+			CategoryID:      good.CategoryID,
+			Attributes:      good.Attributes,
+			PrevName:        &good.Previous.Name,
+			PrevDescription: &good.Previous.Description,
+			PrevPriority:    &good.Previous.Priority,
+			PrevTags:        good.Previous.Tags,
+			PrevCategoryID:  good.Previous.CategoryID,
+			PrevAttributes:  good.Previous.Attributes,
+			EventTime:       time.Now(),
+		}
+
+		if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
+			log.Warn().Err(err).Msgf("failed to publish imported good %d to NATS", good.ID)
+		}
+	}
+
+	event := entity.ImportEvent{
+		ProjectID: request.ProjectID,
+		Mode:      request.Mode,
+		Total:     response.Total,
+		Created:   response.Created,
+		Updated:   response.Updated,
+		Failed:    response.Failed,
+		RequestID: entity.RequestMetaFromContext(ctx).RequestID,
+		EventTime: time.Now(),
+	}
+
+	if principal, ok := entity.PrincipalFromContext(ctx); ok {
+		event.Actor = principal.ID
+	}
+
+	if err := s.natsClient.Publish(ctx, "goods.imports", event); err != nil {
+		log.Warn().Err(err).Msg("failed to publish import to NATS")
+	}
+}
+
+// readImportRows parses the rows of the file. Rows that can't be parsed are
+// returned as errors, while a file that can't be read at all is an error.
+func readImportRows(format string, r io.Reader) ([]entity.ImportRow, []entity.ImportError, error) {
+	if format == entity.FormatJSONL {
+		return readJSONLRows(r)
+	}
+
+	return readCSVRows(r)
+}
+
+func readJSONLRows(r io.Reader) ([]entity.ImportRow, []entity.ImportError, error) {
+	var (
+		rows []entity.ImportRow
+		errs []entity.ImportError
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxImportLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		if len(rows)+len(errs) == entity.MaxImportRows {
+			return nil, nil, fmt.Errorf("%w: at most %d rows", entity.ErrInvalidImport, entity.MaxImportRows)
+		}
+
+		row := entity.ImportRow{Line: line}
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			errs = append(errs, entity.ImportError{Line: line, Error: "invalid json: " + err.Error()})
+
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", entity.ErrInvalidImport, err)
+	}
+
+	return rows, errs, nil
+}
+
+// csvColumns are the known columns, true if they are imported. The other
+// columns of an export are ignored.
+var csvColumns = map[string]bool{
+	"id": true, "external_id": true, "name": true, "description": true, "priority": true, "tags": true, "category_id": true, "attributes": true,
+	"project_id": false, "removed": false, "created_at": false,
+}
+
+func readCSVRows(r io.Reader) ([]entity.ImportRow, []entity.ImportError, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: failed to read csv header: %w", entity.ErrInvalidImport, err)
+	}
+
+	columns := make(map[string]int, len(header))
+
+	for i, column := range header {
+		if _, ok := csvColumns[column]; !ok {
+			return nil, nil, fmt.Errorf("%w: unknown column %q", entity.ErrInvalidImport, column)
+		}
+
+		columns[column] = i
+	}
+
+	if _, ok := columns["name"]; !ok {
+		return nil, nil, fmt.Errorf("%w: name column is required", entity.ErrInvalidImport)
+	}
+
+	var (
+		rows []entity.ImportRow
+		errs []entity.ImportError
+	)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, errs, nil
+		}
+
+		if len(rows)+len(errs) == entity.MaxImportRows {
+			return nil, nil, fmt.Errorf("%w: at most %d rows", entity.ErrInvalidImport, entity.MaxImportRows)
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			errs = append(errs, entity.ImportError{Line: parseErr.StartLine, Error: "wrong number of fields"})
+
+			continue
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %w", entity.ErrInvalidImport, err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		row, err := parseCSVRow(columns, record)
+		if err != nil {
+			errs = append(errs, entity.ImportError{Line: line, Error: err.Error()})
+
+			continue
+		}
+
+		row.Line = line
+		rows = append(rows, row)
+	}
+}
+
+// parseCSVRow reads the row of the columns. Empty values are not set, tags
+// are separated by commas and attributes are a JSON object.
+func parseCSVRow(columns map[string]int, record []string) (entity.ImportRow, error) {
+	var (
+		row entity.ImportRow
+		err error
+	)
+
+	value := func(column string) string {
+		if i, ok := columns[column]; ok {
+			return record[i]
+		}
+
+		return ""
+	}
+
+	row.Name = value("name")
+
+	if externalID := value("external_id"); externalID != "" {
+		row.ExternalID = &externalID
+	}
+
+	if description := value("description"); description != "" {
+		row.Description = &description
+	}
+
+	if id := value("id"); id != "" {
+		if row.ID, err = strconv.Atoi(id); err != nil {
+			return entity.ImportRow{}, fmt.Errorf("invalid id %q", id)
+		}
+	}
+
+	if priority := value("priority"); priority != "" {
+		if row.Priority, err = strconv.Atoi(priority); err != nil {
+			return entity.ImportRow{}, fmt.Errorf("invalid priority %q", priority)
+		}
+	}
+
+	if tags := value("tags"); tags != "" {
+		row.Tags = strings.Split(tags, ",")
+	}
+
+	if categoryID := value("category_id"); categoryID != "" {
+		id, err := strconv.Atoi(categoryID)
+		if err != nil {
+			return entity.ImportRow{}, fmt.Errorf("invalid category_id %q", categoryID)
+		}
+
+		row.CategoryID = &id
+	}
+
+	if attributes := value("attributes"); attributes != "" {
+		if err := json.Unmarshal([]byte(attributes), &row.Attributes); err != nil {
+			return entity.ImportRow{}, fmt.Errorf("attributes must be a json object: %w", err)
+		}
+	}
+
+	return row, nil
+}
@@ -0,0 +1,28 @@
+package goodsservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *Service) SearchGoods(ctx context.Context, request entity.SearchRequest) (entity.SearchResponse, error) {
+	if err := request.Validate(); err != nil {
+		return entity.SearchResponse{}, fmt.Errorf("failed to validate search: %w", err)
+	}
+
+	results, total, err := s.goodsStore.SearchGoods(ctx, request)
+	if err != nil {
+		return entity.SearchResponse{}, fmt.Errorf("failed to search goods: %w", err)
+	}
+
+	return entity.SearchResponse{
+		Meta: entity.SearchMeta{
+			Total:  total,
+			Limit:  request.Limit,
+			Offset: request.Offset,
+		},
+		Results: results,
+	}, nil
+}
@@ -2,8 +2,9 @@ package goodsservice
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/romanpitatelev/hezzl-goods/internal/entity"
@@ -13,34 +14,60 @@ import (
 type goodsStore interface {
 	CreateGood(ctx context.Context, projectID int, req entity.GoodCreateRequest) (entity.Good, error)
 	GetGood(ctx context.Context, id int, projectID int) (entity.Good, error)
-	UpdateGood(ctx context.Context, id int, projectID int, goodUpdate entity.GoodUpdate) (entity.Good, error)
+	UpdateGood(ctx context.Context, id int, projectID int, goodUpdate entity.GoodUpdate) (entity.Good, entity.Good, error)
 	DeleteGood(ctx context.Context, id int, projectID int) (entity.GoodDeleteResponse, error)
 	GetGoods(ctx context.Context, request entity.ListRequest) ([]entity.Good, entity.Meta, error)
 	Reprioritize(ctx context.Context, id int, projectID int, newPriority entity.PriorityRequest) ([]entity.Priority, error)
+	GetTags(ctx context.Context, projectID int) ([]entity.Tag, error)
+	RenameTag(ctx context.Context, projectID int, from, to string) ([]entity.ChangedGood, error)
+	MergeTags(ctx context.Context, projectID int, sources []string, target string) ([]entity.ChangedGood, error)
+	CreateCategory(ctx context.Context, projectID int, req entity.CategoryCreateRequest) (entity.Category, error)
+	GetCategories(ctx context.Context, projectID int) ([]entity.Category, error)
+	RenameCategory(ctx context.Context, id int, projectID int, name string) (entity.Category, error)
+	MoveCategory(ctx context.Context, id int, projectID int, parentID *int) (entity.Category, error)
+	DeleteCategory(ctx context.Context, id int, projectID int) (int, []entity.ChangedGood, error)
+	SetGoodCategory(ctx context.Context, id int, projectID int, categoryID *int) (entity.Good, entity.Good, error)
+	GetAttributeSchema(ctx context.Context, projectID int) (entity.AttributeSchema, error)
+	SetAttributeSchema(ctx context.Context, projectID int, attributes []entity.AttributeDefinition) (entity.AttributeSchema, error)
+	SearchGoods(ctx context.Context, request entity.SearchRequest) ([]entity.SearchResult, int, error)
+	ImportGoods(ctx context.Context, request entity.ImportRequest, rows []entity.ImportRow) (entity.ImportResult, error)
+	GetGoodByExternalID(ctx context.Context, externalID string, projectID int) (entity.Good, error)
+	UpsertGood(ctx context.Context, projectID int, req entity.GoodCreateRequest) (entity.Good, entity.Good, bool, error)
 }
 
 type NATSPublisher interface {
-	Publish(subject string, data interface{}) error
+	Publish(ctx context.Context, subject string, data interface{}) error
 }
 
 type redisClient interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	Del(ctx context.Context, keys ...string) error
+	DelPattern(ctx context.Context, pattern string) error
 }
 
 type Service struct {
 	goodsStore  goodsStore
 	natsClient  NATSPublisher
 	redisClient redisClient
+	cacheConfig atomic.Pointer[CacheConfig]
 }
 
 func New(goodsStore goodsStore, natsClient NATSPublisher, redredisClient redisClient) *Service {
-	return &Service{
+	s := &Service{
 		goodsStore:  goodsStore,
 		natsClient:  natsClient,
 		redisClient: redredisClient,
 	}
+
+	s.SetCacheConfig(DefaultCacheConfig())
+
+	return s
+}
+
+// SetCacheConfig replaces the cache policy. It applies to the next cached values.
+func (s *Service) SetCacheConfig(cfg CacheConfig) {
+	s.cacheConfig.Store(&cfg)
 }
 
 func (s *Service) CreateGood(ctx context.Context, projectID int, req entity.GoodCreateRequest) (entity.Good, error) {
@@ -53,23 +80,33 @@ func (s *Service) CreateGood(ctx context.Context, projectID int, req entity.Good
 		return entity.Good{}, entity.ErrInvalidIDOrProjectID
 	}
 
+	if err := s.validateAttributes(ctx, projectID, req.Attributes); err != nil {
+		return entity.Good{}, err
+	}
+
 	createdGood, err := s.goodsStore.CreateGood(ctx, projectID, req)
 	if err != nil {
 		return entity.Good{}, fmt.Errorf("failed to create good: %w", err)
 	}
 
+	s.invalidate(ctx, createdGood.ProjectID, createdGood.ID)
+
 	logMsg := entity.GoodLog{
 		Operation:   "create",
 		GoodID:      createdGood.ID,
 		ProjectID:   createdGood.ProjectID,
+		ExternalID:  createdGood.ExternalID,
 		Name:        createdGood.Name,
 		Description: createdGood.Description,
 		Priority:    createdGood.Priority,
 		Removed:     createdGood.Removed,
+		Tags:        createdGood.Tags,
+		CategoryID:  createdGood.CategoryID,
+		Attributes:  createdGood.Attributes,
 		EventTime:   time.Now(),
 	}
 
-	if err := s.natsClient.Publish("goods.logs", logMsg); err != nil {
+	if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
 		log.Warn().Err(err).Msg("failed to publish new good to NATS")
 	}
 
@@ -81,42 +118,45 @@ func (s *Service) GetGood(ctx context.Context, id int, projectID int) (entity.Go
 		return entity.Good{}, entity.ErrInvalidIDOrProjectID
 	}
 
-	cacheKey := fmt.Sprintf("good:%d:%d", id, projectID)
-	cached, err := s.redisClient.Get(ctx, cacheKey)
+	cacheKey := goodCacheKey(id, projectID)
+
+	var good entity.Good
 
-	if err == nil && cached != "" {
-		var good entity.Good
-		if err := json.Unmarshal([]byte(cached), &good); err == nil {
-			return good, nil
+	if cached, err := s.fromCache(ctx, cacheGood, cacheKey, &good); cached {
+		if err != nil {
+			return entity.Good{}, fmt.Errorf("failed to get good: %w", err)
 		}
+
+		return good, nil
 	}
 
 	good, err := s.goodsStore.GetGood(ctx, id, projectID)
-	if err != nil {
-		return entity.Good{}, fmt.Errorf("failed to get good: %w", err)
+	if errors.Is(err, entity.ErrGoodNotFound) {
+		s.setCache(ctx, cacheGood, cacheKey, []byte(notFoundValue), s.cacheConfig.Load().NotFoundTTL)
 	}
 
-	jsonData, err := json.Marshal(good)
 	if err != nil {
-		return entity.Good{}, fmt.Errorf("failed to marshal good for caching: %w", err)
+		return entity.Good{}, fmt.Errorf("failed to get good: %w", err)
 	}
 
-	if err := s.redisClient.Set(ctx, cacheKey, jsonData, time.Minute); err != nil {
-		log.Warn().Err(err).Msg("failed to cache good")
-	}
+	s.toCache(ctx, cacheGood, cacheKey, good, s.cacheConfig.Load().GoodTTL)
 
 	logMsg := entity.GoodLog{
 		Operation:   "get",
 		GoodID:      good.ID,
 		ProjectID:   good.ProjectID,
+		ExternalID:  good.ExternalID,
 		Name:        good.Name,
 		Description: good.Description,
 		Priority:    good.Priority,
 		Removed:     good.Removed,
+		Tags:        good.Tags,
+		CategoryID:  good.CategoryID,
+		Attributes:  good.Attributes,
 		EventTime:   time.Now(),
 	}
 
-	if err := s.natsClient.Publish("goods.logs", logMsg); err != nil {
+	if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
 		log.Warn().Err(err).Msg("failed to publish get good to NATS")
 	}
 
@@ -132,32 +172,49 @@ func (s *Service) UpdateGood(ctx context.Context, id int, projectID int, goodUpd
 		return entity.Good{}, entity.ErrEmptyName
 	}
 
-	updatedGood, err := s.goodsStore.UpdateGood(ctx, id, projectID, goodUpdate)
-	if err != nil {
-		return entity.Good{}, fmt.Errorf("failed to update good: %w", err)
-	}
+	if goodUpdate.Tags != nil {
+		tags, err := entity.NormalizeTags(*goodUpdate.Tags)
+		if err != nil {
+			return entity.Good{}, fmt.Errorf("failed to validate good: %w", err)
+		}
 
-	cacheKeys := []string{
-		fmt.Sprintf("good:%d:%d", id, projectID),
-		"goods:list:*",
+		goodUpdate.Tags = &tags
 	}
 
-	if err := s.redisClient.Del(ctx, cacheKeys...); err != nil {
-		log.Warn().Err(err).Msg("failed to invalidate redis cache")
+	if goodUpdate.Attributes != nil {
+		if err := s.validateAttributes(ctx, projectID, goodUpdate.Attributes); err != nil {
+			return entity.Good{}, err
+		}
 	}
 
-	logMsg := entity.GoodLog{
-		Operation:   "update",
-		GoodID:      updatedGood.ID,
-		ProjectID:   updatedGood.ProjectID,
-		Name:        updatedGood.Name,
-		Description: updatedGood.Description,
-		Priority:    updatedGood.Priority,
-		Removed:     updatedGood.Removed,
-		EventTime:   time.Now(),
+	updatedGood, previousGood, err := s.goodsStore.UpdateGood(ctx, id, projectID, goodUpdate)
+	if err != nil {
+		return entity.Good{}, fmt.Errorf("failed to update good: %w", err)
 	}
 
-	if err := s.natsClient.Publish("goods.logs", logMsg); err != nil {
+	s.invalidate(ctx, projectID, id)
+
+	logMsg := entity.GoodLog{
+		Operation:       "update",
+		GoodID:          updatedGood.ID,
+		ProjectID:       updatedGood.ProjectID,
+		ExternalID:      updatedGood.ExternalID,
+		Name:            updatedGood.Name,
+		Description:     updatedGood.Description,
+		Priority:        updatedGood.Priority,
+		Removed:         updatedGood.Removed,
+		Tags:            updatedGood.Tags,
+		CategoryID:      updatedGood.CategoryID,
+		Attributes:      updatedGood.Attributes,
+		PrevName:        &previousGood.Name,
+		PrevDescription: &previousGood.Description,
+		PrevPriority:    &previousGood.Priority,
+		PrevTags:        previousGood.Tags,
+		PrevAttributes:  previousGood.Attributes,
+		EventTime:       time.Now(),
+	}
+
+	if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
 		log.Warn().Err(err).Msg("failed to publish updated good to NATS")
 	}
 
@@ -174,13 +231,7 @@ func (s *Service) DeleteGood(ctx context.Context, id int, projectID int) (entity
 		return entity.GoodDeleteResponse{}, fmt.Errorf("failed to delete good: %w", err)
 	}
 
-	cacheKeys := []string{
-		fmt.Sprintf("good:%d:%d", id, projectID),
-		"goods:list:*",
-	}
-	if err := s.redisClient.Del(ctx, cacheKeys...); err != nil {
-		log.Warn().Err(err).Msg("failed to invalidate redis cache while deleting")
-	}
+	s.invalidate(ctx, projectID, id)
 
 	logMsg := entity.GoodLog{
 		Operation:   "delete",
@@ -190,10 +241,11 @@ func (s *Service) DeleteGood(ctx context.Context, id int, projectID int) (entity
 		Description: "",
 		Priority:    0,
 		Removed:     true,
+		ExternalID:  deletedGood.ExternalID,
 		EventTime:   time.Now(),
 	}
 
-	if err := s.natsClient.Publish("goods.logs", logMsg); err != nil {
+	if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
 		log.Warn().Err(err).Msg("failed to publish deleted good to NATS")
 	}
 
@@ -203,16 +255,20 @@ func (s *Service) DeleteGood(ctx context.Context, id int, projectID int) (entity
 func (s *Service) GetGoods(ctx context.Context, request entity.ListRequest) (entity.GoodsListResponse, error) {
 	request.Validate()
 
-	cacheKey := fmt.Sprintf("goods:list:%d:%d", request.Limit, request.Offset)
-	cached, err := s.redisClient.Get(ctx, cacheKey)
+	if err := request.ValidateTags(); err != nil {
+		return entity.GoodsListResponse{}, fmt.Errorf("failed to validate tags: %w", err)
+	}
 
-	if err != nil && cached != "" {
-		var response entity.GoodsListResponse
-		if err := json.Unmarshal([]byte(cached), &response); err == nil {
-			return response, nil
-		}
+	if err := s.validateAttributeFilters(ctx, &request); err != nil {
+		return entity.GoodsListResponse{}, err
+	}
 
-		log.Warn().Err(err).Msg("error unmarshalling cached goods")
+	cacheKey := listCacheKey(request)
+
+	var response entity.GoodsListResponse
+
+	if cached, _ := s.fromCache(ctx, cacheList, cacheKey, &response); cached {
+		return response, nil
 	}
 
 	goods, meta, err := s.goodsStore.GetGoods(ctx, request)
@@ -220,17 +276,12 @@ func (s *Service) GetGoods(ctx context.Context, request entity.ListRequest) (ent
 		return entity.GoodsListResponse{}, fmt.Errorf("failed to get goods: %w", err)
 	}
 
-	response := entity.GoodsListResponse{
+	response = entity.GoodsListResponse{
 		Meta:  meta,
 		Goods: goods,
 	}
 
-	jsonData, err := json.Marshal(response)
-	if err == nil {
-		if err := s.redisClient.Set(ctx, cacheKey, jsonData, time.Minute); err != nil {
-			log.Warn().Err(err).Msg("failed to cache goods list")
-		}
-	}
+	s.toCache(ctx, cacheList, cacheKey, response, s.cacheConfig.Load().ListTTL)
 
 	return response, nil
 }
@@ -249,27 +300,25 @@ func (s *Service) Reprioritize(ctx context.Context, id int, projectID int, req e
 		return entity.PriorityResponse{}, fmt.Errorf("failed to reprioritize: %w", err)
 	}
 
-	cacheKeys := make([]string, 0, len(updatedPriorities)+1)
+	ids := make([]int, 0, len(updatedPriorities))
 	for _, p := range updatedPriorities {
-		cacheKeys = append(cacheKeys, fmt.Sprintf("good:%d:%d", p.ID, projectID))
+		ids = append(ids, p.ID)
 	}
 
-	cacheKeys = append(cacheKeys, "goods:list:*")
-
-	if err := s.redisClient.Del(ctx, cacheKeys...); err != nil {
-		log.Warn().Err(err).Msg("failed to invalidate redis cache")
-	}
+	s.invalidate(ctx, projectID, ids...)
 
 	for _, p := range updatedPriorities {
 		logMsg := entity.GoodLog{
-			Operation: "reprioritize",
-			GoodID:    p.ID,
-			ProjectID: projectID,
-			Priority:  p.Priority,
-			EventTime: time.Now(),
+			Operation:    "reprioritize",
+			GoodID:       p.ID,
+			ProjectID:    projectID,
+			Priority:     p.Priority,
+			PrevPriority: &p.PreviousPriority,
+			ExternalID:   p.ExternalID,
+			EventTime:    time.Now(),
 		}
 
-		if err := s.natsClient.Publish("goods.logs", logMsg); err != nil {
+		if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
 			log.Warn().Err(err).Msgf("failed to publish log for good %d", p.ID)
 		}
 	}
@@ -278,3 +327,71 @@ func (s *Service) Reprioritize(ctx context.Context, id int, projectID int, req e
 		Priorities: updatedPriorities,
 	}, nil
 }
+
+// changed invalidates the cache of goods changed by an operation on many goods
+// and publishes their updates.
+func (s *Service) changed(ctx context.Context, projectID int, goods []entity.ChangedGood) {
+	ids := make([]int, 0, len(goods))
+	for _, good := range goods {
+		ids = append(ids, good.ID)
+	}
+
+	s.invalidate(ctx, projectID, ids...)
+
+	for _, good := range goods {
+		logMsg := entity.GoodLog{
+			Operation:      "update",
+			GoodID:         good.ID,
+			ProjectID:      good.ProjectID,
+			ExternalID:     good.ExternalID,
+			Name:           good.Name,
+			Description:    good.Description,
+			Priority:       good.Priority,
+			Removed:        good.Removed,
+			Tags:           good.Tags,
+			CategoryID:     good.CategoryID,
+			Attributes:     good.Attributes,
+			PrevTags:       good.PrevTags,
+			PrevCategoryID: good.PrevCategoryID,
+			EventTime:      time.Now(),
+		}
+
+		if err := s.natsClient.Publish(ctx, "goods.logs", withAuditMeta(ctx, logMsg)); err != nil {
+			log.Warn().Err(err).Msgf("failed to publish changed good %d to NATS", good.ID)
+		}
+	}
+}
+
+// lastLogID is the ID of the last goods log published by the instance.
+var lastLogID atomic.Uint64
+
+// nextLogID returns the ID of a new goods log. IDs are the event time in
+// nanoseconds, and grow within the instance even when logs are published in the
+// same nanosecond. Clocks of instances differ, so IDs don't order the logs of
+// several instances; streams number events themselves.
+func nextLogID() uint64 {
+	for {
+		last := lastLogID.Load()
+		id := max(uint64(time.Now().UnixNano()), last+1) //nolint:gosec
+
+		if lastLogID.CompareAndSwap(last, id) {
+			return id
+		}
+	}
+}
+
+// withAuditMeta records who made the change and where the request came from.
+func withAuditMeta(ctx context.Context, logMsg entity.GoodLog) entity.GoodLog {
+	logMsg.ID = nextLogID()
+
+	if principal, ok := entity.PrincipalFromContext(ctx); ok {
+		logMsg.Actor = principal.ID
+	}
+
+	meta := entity.RequestMetaFromContext(ctx)
+	logMsg.ClientIP = meta.ClientIP
+	logMsg.UserAgent = meta.UserAgent
+	logMsg.RequestID = meta.RequestID
+
+	return logMsg
+}
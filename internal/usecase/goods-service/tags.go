@@ -0,0 +1,79 @@
+package goodsservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *Service) GetTags(ctx context.Context, projectID int) (entity.TagsResponse, error) {
+	if projectID <= 0 {
+		return entity.TagsResponse{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	tags, err := s.goodsStore.GetTags(ctx, projectID)
+	if err != nil {
+		return entity.TagsResponse{}, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	return entity.TagsResponse{Tags: tags}, nil
+}
+
+func (s *Service) RenameTag(ctx context.Context, projectID int, req entity.TagRenameRequest) (entity.TagChangeResponse, error) {
+	if projectID <= 0 {
+		return entity.TagChangeResponse{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	from, err := entity.NormalizeTag(req.From)
+	if err != nil {
+		return entity.TagChangeResponse{}, fmt.Errorf("failed to validate tag: %w", err)
+	}
+
+	to, err := entity.NormalizeTag(req.To)
+	if err != nil {
+		return entity.TagChangeResponse{}, fmt.Errorf("failed to validate tag: %w", err)
+	}
+
+	if from == to {
+		return entity.TagChangeResponse{}, fmt.Errorf("%w: %s is renamed to itself", entity.ErrTagExists, from)
+	}
+
+	goods, err := s.goodsStore.RenameTag(ctx, projectID, from, to)
+	if err != nil {
+		return entity.TagChangeResponse{}, fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	s.changed(ctx, projectID, goods)
+
+	return entity.TagChangeResponse{Updated: len(goods)}, nil
+}
+
+func (s *Service) MergeTags(ctx context.Context, projectID int, req entity.TagMergeRequest) (entity.TagChangeResponse, error) {
+	if projectID <= 0 {
+		return entity.TagChangeResponse{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	if len(req.Sources) == 0 {
+		return entity.TagChangeResponse{}, fmt.Errorf("%w: no tags to merge", entity.ErrInvalidTag)
+	}
+
+	sources, err := entity.NormalizeTags(req.Sources)
+	if err != nil {
+		return entity.TagChangeResponse{}, fmt.Errorf("failed to validate tags: %w", err)
+	}
+
+	target, err := entity.NormalizeTag(req.Target)
+	if err != nil {
+		return entity.TagChangeResponse{}, fmt.Errorf("failed to validate tag: %w", err)
+	}
+
+	goods, err := s.goodsStore.MergeTags(ctx, projectID, sources, target)
+	if err != nil {
+		return entity.TagChangeResponse{}, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	s.changed(ctx, projectID, goods)
+
+	return entity.TagChangeResponse{Updated: len(goods)}, nil
+}
@@ -0,0 +1,110 @@
+package healthservice
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type Config struct {
+	// Timeout limits every dependency check.
+	Timeout time.Duration
+}
+
+type dependency struct {
+	pinger   pinger
+	optional bool
+}
+
+type Service struct {
+	cfg          Config
+	dependencies map[string]dependency
+}
+
+func New(cfg Config) *Service {
+	return &Service{
+		cfg:          cfg,
+		dependencies: make(map[string]dependency),
+	}
+}
+
+// AddDependency adds a dependency the service can't work without.
+// It must be called before serving.
+func (s *Service) AddDependency(name string, pinger pinger) {
+	s.dependencies[name] = dependency{pinger: pinger}
+}
+
+// AddOptionalDependency adds a dependency the service works without in
+// degraded mode. It must be called before serving.
+func (s *Service) AddOptionalDependency(name string, pinger pinger) {
+	s.dependencies[name] = dependency{pinger: pinger, optional: true}
+}
+
+// Readiness pings all dependencies concurrently. The service is down when a
+// required dependency doesn't answer within the timeout, and degraded when an
+// optional one doesn't.
+func (s *Service) Readiness(ctx context.Context) entity.HealthReport {
+	report := entity.HealthReport{
+		Status: entity.HealthStatusOK,
+		Checks: make(map[string]entity.HealthCheck, len(s.dependencies)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for name, dep := range s.dependencies {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			check := s.check(ctx, dep.pinger)
+			check.Optional = dep.optional
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Checks[name] = check
+
+			switch {
+			case check.Status == entity.HealthStatusOK:
+			case !dep.optional:
+				report.Status = entity.HealthStatusDown
+			case report.Status == entity.HealthStatusOK:
+				report.Status = entity.HealthStatusDegraded
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+func (s *Service) check(ctx context.Context, dependency pinger) entity.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dependency.Ping(ctx)
+
+	check := entity.HealthCheck{
+		Status:    entity.HealthStatusOK,
+		LatencyMS: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		check.Status = entity.HealthStatusDown
+		check.Error = err.Error()
+	}
+
+	return check
+}
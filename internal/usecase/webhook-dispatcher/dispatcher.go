@@ -0,0 +1,413 @@
+package webhookdispatcher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	queueGroup   = "webhooks"
+	jobsCapacity = 1000
+
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+)
+
+type Config struct {
+	Subject          string
+	Workers          int
+	MaxAttempts      int
+	InitialBackoff   time.Duration
+	MaxBackoff       time.Duration
+	Timeout          time.Duration
+	DisableThreshold int
+	// AllowPrivateTargets allows deliveries to loopback, private and
+	// link-local addresses.
+	AllowPrivateTargets bool
+}
+
+type webhooksStore interface {
+	GetActiveWebhooks(ctx context.Context, projectID int) ([]entity.Webhook, error)
+	RecordDelivery(ctx context.Context, delivery entity.WebhookDelivery) error
+	MarkDelivered(ctx context.Context, id int) error
+	MarkFailed(ctx context.Context, id int, disableThreshold int) (bool, error)
+}
+
+type Dispatcher struct {
+	cfg           Config
+	natsConn      *nats.Conn
+	webhooksStore webhooksStore
+	httpClient    *http.Client
+	events        chan entity.GoodLog
+	retries       chan delivery
+	stop          chan struct{}
+	wg            sync.WaitGroup
+
+	mu     sync.Mutex
+	timers map[*time.Timer]delivery
+}
+
+// delivery is an attempt to send an event to a webhook.
+type delivery struct {
+	webhook entity.Webhook
+	logMsg  entity.GoodLog
+	payload []byte
+	attempt int
+}
+
+func New(cfg Config, natsConn *nats.Conn, webhooksStore webhooksStore) *Dispatcher {
+	return &Dispatcher{
+		cfg:           cfg,
+		natsConn:      natsConn,
+		webhooksStore: webhooksStore,
+		httpClient:    newHTTPClient(cfg),
+		events:        make(chan entity.GoodLog, jobsCapacity),
+		retries:       make(chan delivery),
+		stop:          make(chan struct{}),
+		timers:        make(map[*time.Timer]delivery),
+	}
+}
+
+// Start delivers events until ctx is done. Then it stops taking events, gives
+// up the retries waiting for their backoff and delivers the queued events.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	subscription, err := d.natsConn.QueueSubscribe(d.cfg.Subject, queueGroup, func(msg *nats.Msg) {
+		var logMsg entity.GoodLog
+		if err := json.Unmarshal(msg.Data, &logMsg); err != nil {
+			log.Warn().Err(err).Msg("failed to unmarshal message in webhook dispatcher")
+
+			return
+		}
+
+		if !logMsg.IsChange() {
+			return
+		}
+
+		select {
+		case d.events <- logMsg:
+		default:
+			metrics.WebhookDroppedEvents.Inc()
+			log.Warn().Int("goodId", logMsg.GoodID).Msg("webhook queue is full, dropping event")
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe webhook dispatcher: %w", err)
+	}
+
+	// Deliveries in flight and queued ones are finished after ctx is done.
+	deliveryCtx := context.WithoutCancel(ctx)
+
+	for range d.cfg.Workers {
+		d.wg.Add(1)
+
+		go d.work(deliveryCtx)
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		if err := subscription.Unsubscribe(); err != nil {
+			log.Warn().Err(err).Msg("failed to unsubscribe webhook dispatcher")
+		}
+
+		d.stopRetries(deliveryCtx)
+	}()
+
+	return nil
+}
+
+func (d *Dispatcher) Wait() {
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) work(ctx context.Context) {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-d.stop:
+			d.drain(ctx)
+
+			return
+		case logMsg := <-d.events:
+			d.dispatch(ctx, logMsg)
+		case job := <-d.retries:
+			d.deliver(ctx, job)
+		}
+	}
+}
+
+// drain delivers the queued events and the retries whose backoff has passed.
+func (d *Dispatcher) drain(ctx context.Context) {
+	for {
+		select {
+		case logMsg := <-d.events:
+			d.dispatch(ctx, logMsg)
+		case job := <-d.retries:
+			d.deliver(ctx, job)
+		default:
+			return
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, logMsg entity.GoodLog) {
+	webhooks, err := d.webhooksStore.GetActiveWebhooks(ctx, logMsg.ProjectID)
+	if err != nil {
+		log.Error().Err(err).Int("projectId", logMsg.ProjectID).Msg("failed to get webhooks")
+
+		return
+	}
+
+	if len(webhooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(logMsg)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to marshal webhook payload")
+
+		return
+	}
+
+	for _, webhook := range webhooks {
+		d.deliver(ctx, delivery{
+			webhook: webhook,
+			logMsg:  logMsg,
+			payload: payload,
+			attempt: 1,
+		})
+	}
+}
+
+// deliver makes one attempt and schedules the next one when it fails.
+func (d *Dispatcher) deliver(ctx context.Context, job delivery) {
+	statusCode, err := d.send(ctx, job.webhook, job.logMsg.Operation, job.payload)
+
+	record := entity.WebhookDelivery{
+		WebhookID: job.webhook.ID,
+		Operation: job.logMsg.Operation,
+		GoodID:    job.logMsg.GoodID,
+		Attempt:   job.attempt,
+		Success:   err == nil,
+	}
+
+	if statusCode != 0 {
+		record.StatusCode = &statusCode
+	}
+
+	if err != nil {
+		errText := err.Error()
+		record.Error = &errText
+	}
+
+	if recordErr := d.webhooksStore.RecordDelivery(ctx, record); recordErr != nil {
+		log.Warn().Err(recordErr).Int("webhookId", job.webhook.ID).Msg("failed to record webhook delivery")
+	}
+
+	if err == nil {
+		if err := d.webhooksStore.MarkDelivered(ctx, job.webhook.ID); err != nil {
+			log.Warn().Err(err).Int("webhookId", job.webhook.ID).Msg("failed to reset webhook failures")
+		}
+
+		return
+	}
+
+	log.Debug().Err(err).Int("webhookId", job.webhook.ID).Int("attempt", job.attempt).Msg("webhook delivery failed")
+
+	if job.attempt == d.cfg.MaxAttempts {
+		d.fail(ctx, job.webhook)
+
+		return
+	}
+
+	d.scheduleRetry(ctx, job)
+}
+
+// scheduleRetry hands the next attempt to the workers after the backoff, so
+// that workers don't wait for it.
+func (d *Dispatcher) scheduleRetry(ctx context.Context, job delivery) {
+	d.mu.Lock()
+
+	select {
+	case <-d.stop:
+		d.mu.Unlock()
+		d.giveUp(ctx, job)
+
+		return
+	default:
+	}
+
+	backoff := d.backoff(job.attempt)
+	job.attempt++
+
+	d.wg.Add(1)
+
+	var timer *time.Timer
+
+	timer = time.AfterFunc(backoff, func() {
+		defer d.wg.Done()
+
+		d.mu.Lock()
+		delete(d.timers, timer)
+		d.mu.Unlock()
+
+		select {
+		case d.retries <- job:
+		case <-d.stop:
+			d.giveUp(ctx, job)
+		}
+	})
+
+	d.timers[timer] = job
+	d.mu.Unlock()
+}
+
+// stopRetries gives up the retries waiting for their backoff and tells the
+// workers to drain the queue.
+func (d *Dispatcher) stopRetries(ctx context.Context) {
+	d.mu.Lock()
+
+	close(d.stop)
+
+	var stopped []delivery
+
+	for timer, job := range d.timers {
+		if timer.Stop() {
+			stopped = append(stopped, job)
+		}
+	}
+
+	clear(d.timers)
+	d.mu.Unlock()
+
+	for _, job := range stopped {
+		d.giveUp(ctx, job)
+		d.wg.Done()
+	}
+}
+
+func (d *Dispatcher) giveUp(ctx context.Context, job delivery) {
+	log.Warn().Int("webhookId", job.webhook.ID).Int("attempt", job.attempt).Msg("giving up webhook retry on shutdown")
+	d.fail(ctx, job.webhook)
+}
+
+// fail counts an event that couldn't be delivered and disables the webhook
+// after DisableThreshold of them in a row.
+func (d *Dispatcher) fail(ctx context.Context, webhook entity.Webhook) {
+	disabled, err := d.webhooksStore.MarkFailed(ctx, webhook.ID, d.cfg.DisableThreshold)
+	if err != nil {
+		log.Warn().Err(err).Int("webhookId", webhook.ID).Msg("failed to count webhook failure")
+
+		return
+	}
+
+	if disabled {
+		log.Warn().Int("webhookId", webhook.ID).Str("url", webhook.URL).Msg("webhook disabled after repeated failures")
+	}
+}
+
+func (d *Dispatcher) send(ctx context.Context, webhook entity.Webhook, operation string, payload []byte) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, operation)
+	request.Header.Set(TimestampHeader, timestamp)
+	request.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, payload))
+
+	response, err := d.httpClient.Do(request)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			log.Debug().Err(err).Msg("failed to close webhook response body")
+		}
+	}()
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return response.StatusCode, fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	backoff := d.cfg.InitialBackoff << (attempt - 1)
+	if backoff <= 0 || backoff > d.cfg.MaxBackoff {
+		return d.cfg.MaxBackoff
+	}
+
+	return backoff
+}
+
+func newHTTPClient(cfg Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+
+	if !cfg.AllowPrivateTargets {
+		dialer := &net.Dialer{
+			Timeout: cfg.Timeout,
+			Control: rejectPrivateAddress,
+		}
+
+		// A proxy would connect to the target instead of the dialer.
+		transport.Proxy = nil
+		transport.DialContext = dialer.DialContext
+	}
+
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+	}
+}
+
+// rejectPrivateAddress runs with the resolved address of every connection, so
+// names and redirects leading to addresses that aren't public are rejected too.
+func rejectPrivateAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("failed to parse webhook address: %w", err)
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !entity.IsPublicAddress(addr) {
+		return fmt.Errorf("%w: %s", entity.ErrWebhookTargetNotPublic, host)
+	}
+
+	return nil
+}
+
+// Sign returns the signature sent in the X-Webhook-Signature header:
+// hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
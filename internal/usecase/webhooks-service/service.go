@@ -0,0 +1,133 @@
+package webhooksservice
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+const secretLength = 32
+
+type webhooksStore interface {
+	CreateWebhook(ctx context.Context, projectID int, req entity.WebhookCreateRequest) (entity.Webhook, error)
+	GetWebhooks(ctx context.Context, projectID int) ([]entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int, projectID int) error
+	EnableWebhook(ctx context.Context, id int, projectID int) (entity.Webhook, error)
+	GetDeliveries(ctx context.Context, webhookID int, projectID int) ([]entity.WebhookDelivery, error)
+}
+
+type Config struct {
+	// AllowPrivateTargets allows webhooks to localhost and private addresses.
+	AllowPrivateTargets bool
+}
+
+type Service struct {
+	cfg           Config
+	webhooksStore webhooksStore
+}
+
+func New(cfg Config, webhooksStore webhooksStore) *Service {
+	return &Service{
+		cfg:           cfg,
+		webhooksStore: webhooksStore,
+	}
+}
+
+func (s *Service) CreateWebhook(ctx context.Context, projectID int, req entity.WebhookCreateRequest) (entity.Webhook, error) {
+	if projectID <= 0 {
+		return entity.Webhook{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	if err := req.Validate(); err != nil {
+		return entity.Webhook{}, fmt.Errorf("failed to validate webhook: %w", err)
+	}
+
+	if !s.cfg.AllowPrivateTargets {
+		if err := req.ValidateTarget(); err != nil {
+			return entity.Webhook{}, fmt.Errorf("failed to validate webhook: %w", err)
+		}
+	}
+
+	if req.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return entity.Webhook{}, err
+		}
+
+		req.Secret = secret
+	}
+
+	webhook, err := s.webhooksStore.CreateWebhook(ctx, projectID, req)
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) GetWebhooks(ctx context.Context, projectID int) (entity.WebhooksListResponse, error) {
+	if projectID <= 0 {
+		return entity.WebhooksListResponse{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	webhooks, err := s.webhooksStore.GetWebhooks(ctx, projectID)
+	if err != nil {
+		return entity.WebhooksListResponse{}, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	return entity.WebhooksListResponse{
+		Webhooks: webhooks,
+	}, nil
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, id int, projectID int) error {
+	if id <= 0 || projectID <= 0 {
+		return entity.ErrInvalidIDOrProjectID
+	}
+
+	if err := s.webhooksStore.DeleteWebhook(ctx, id, projectID); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) EnableWebhook(ctx context.Context, id int, projectID int) (entity.Webhook, error) {
+	if id <= 0 || projectID <= 0 {
+		return entity.Webhook{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	webhook, err := s.webhooksStore.EnableWebhook(ctx, id, projectID)
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("failed to enable webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Service) GetDeliveries(ctx context.Context, id int, projectID int) (entity.WebhookDeliveriesResponse, error) {
+	if id <= 0 || projectID <= 0 {
+		return entity.WebhookDeliveriesResponse{}, entity.ErrInvalidIDOrProjectID
+	}
+
+	deliveries, err := s.webhooksStore.GetDeliveries(ctx, id, projectID)
+	if err != nil {
+		return entity.WebhookDeliveriesResponse{}, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	return entity.WebhookDeliveriesResponse{
+		Deliveries: deliveries,
+	}, nil
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return hex.EncodeToString(secret), nil
+}
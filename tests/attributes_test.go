@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *IntegrationTestSuite) TestAttributes() {
+	maxWeight := 100.0
+
+	s.sendRequest(http.MethodPut, "/api/v1/attributes/schema?projectId=1", http.StatusOK,
+		&entity.AttributeSchemaRequest{Attributes: []entity.AttributeDefinition{
+			{Name: "color", Type: entity.AttributeString, Required: true, Enum: []string{"red", "green", "blue"}},
+			{Name: "weight", Type: entity.AttributeNumber, Max: &maxWeight},
+			{Name: "stock", Type: entity.AttributeInteger},
+			{Name: "fragile", Type: entity.AttributeBoolean},
+		}}, nil)
+
+	createGood := func(name string, attributes map[string]any) entity.Good {
+		var good entity.Good
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: name, Attributes: attributes}, &good)
+
+		return good
+	}
+
+	listByAttributes := func(query string) []string {
+		var list entity.GoodsListResponse
+
+		s.sendRequest(http.MethodGet, "/api/v1/goods/list?projectId=1&"+query, http.StatusOK, nil, &list)
+
+		names := make([]string, 0, len(list.Goods))
+		for _, good := range list.Goods {
+			names = append(names, good.Name)
+		}
+
+		return names
+	}
+
+	apple := createGood("apple", map[string]any{"color": "red", "weight": 0.2, "stock": 10})
+	createGood("pear", map[string]any{"color": "green", "weight": 0.3, "fragile": false})
+	createGood("vase", map[string]any{"color": "blue", "weight": 2.5, "stock": 1, "fragile": true})
+
+	s.Run("schema is returned", func() {
+		var schema entity.AttributeSchema
+
+		s.sendRequest(http.MethodGet, "/api/v1/attributes/schema?projectId=1", http.StatusOK, nil, &schema)
+		s.Require().Len(schema.Attributes, 4)
+		s.Require().NotNil(schema.UpdatedAt)
+	})
+
+	s.Run("invalid schemas are rejected", func() {
+		for _, attributes := range [][]entity.AttributeDefinition{
+			{{Name: "Color", Type: entity.AttributeString}},
+			{{Name: "size", Type: "date"}},
+			{{Name: "size", Type: entity.AttributeInteger, Enum: []string{"1"}}},
+			{{Name: "size", Type: entity.AttributeString}, {Name: "size", Type: entity.AttributeNumber}},
+		} {
+			s.sendRequest(http.MethodPut, "/api/v1/attributes/schema?projectId=1", http.StatusBadRequest,
+				&entity.AttributeSchemaRequest{Attributes: attributes}, nil)
+		}
+	})
+
+	s.Run("values are checked against the schema", func() {
+		s.Require().Equal(map[string]any{"color": "red", "weight": 0.2, "stock": 10.0}, apple.Attributes)
+
+		for _, attributes := range []map[string]any{
+			{"weight": 1},
+			{"color": "pink"},
+			{"color": "red", "weight": 101},
+			{"color": "red", "stock": 1.5},
+			{"color": "red", "fragile": "yes"},
+			{"color": "red", "size": "xl"},
+		} {
+			s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusBadRequest,
+				&entity.GoodCreateRequest{Name: "invalid", Attributes: attributes}, nil)
+		}
+	})
+
+	s.Run("list filters by attributes", func() {
+		s.Require().ElementsMatch([]string{"apple"}, listByAttributes("attr.color=eq:red"))
+		s.Require().ElementsMatch([]string{"pear", "vase"}, listByAttributes("attr.color=ne:red"))
+		s.Require().ElementsMatch([]string{"apple", "pear"}, listByAttributes("attr.color=in:red,green"))
+		s.Require().ElementsMatch([]string{"pear", "vase"}, listByAttributes("attr.weight=gte:0.3"))
+		s.Require().ElementsMatch([]string{"apple"}, listByAttributes("attr.weight=lt:1&attr.stock=gt:5"))
+		s.Require().ElementsMatch([]string{"vase"}, listByAttributes("attr.fragile=eq:true"))
+	})
+
+	s.Run("invalid filters are rejected", func() {
+		for _, query := range []string{
+			"projectId=1&attr.size=eq:xl",
+			"projectId=1&attr.color=gt:red",
+			"projectId=1&attr.weight=eq:heavy",
+			"projectId=1&attr.color=like:red",
+			"attr.color=eq:red",
+		} {
+			s.sendRequest(http.MethodGet, "/api/v1/goods/list?"+query, http.StatusBadRequest, nil, nil)
+		}
+	})
+
+	s.Run("update replaces attributes only when they are set", func() {
+		var good entity.Good
+
+		s.sendRequest(http.MethodPatch, goodsPath+fmt.Sprintf("/update?id=%d&projectId=1", apple.ID), http.StatusOK,
+			&entity.GoodUpdate{Name: "apple"}, &good)
+		s.Require().Equal(apple.Attributes, good.Attributes)
+
+		s.sendRequest(http.MethodPatch, goodsPath+fmt.Sprintf("/update?id=%d&projectId=1", apple.ID), http.StatusOK,
+			&entity.GoodUpdate{Name: "apple", Attributes: map[string]any{"color": "green"}}, &good)
+		s.Require().Equal(map[string]any{"color": "green"}, good.Attributes)
+		s.Require().ElementsMatch([]string{"apple", "pear"}, listByAttributes("attr.color=eq:green"))
+
+		s.sendRequest(http.MethodPatch, goodsPath+fmt.Sprintf("/update?id=%d&projectId=1", apple.ID), http.StatusBadRequest,
+			&entity.GoodUpdate{Name: "apple", Attributes: map[string]any{}}, nil)
+	})
+}
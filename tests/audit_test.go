@@ -0,0 +1,55 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *IntegrationTestSuite) TestAuditMetadata() {
+	var createdGood entity.Good
+
+	s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+		&entity.GoodCreateRequest{Name: "audited"}, &createdGood)
+
+	path := goodsPath + fmt.Sprintf("/update?id=%d&projectId=%d", createdGood.ID, createdGood.ProjectID)
+
+	// The consumer writes to ClickHouse in batches, so send enough updates to flush the first one.
+	for i := range 60 {
+		headers := map[string]string{
+			"X-Request-ID":    fmt.Sprintf("audit-%d", i),
+			"User-Agent":      "audit-test",
+			"X-Forwarded-For": "203.0.113.7, 10.0.0.1",
+		}
+
+		s.sendRequestWithHeaders(port, http.MethodPatch, path, headers, http.StatusOK,
+			&entity.GoodUpdate{Name: fmt.Sprintf("renamed_%d", i)}, nil)
+	}
+
+	var (
+		name, clientIP, userAgent string
+		prevName                  sql.NullString
+		prevPriority              sql.NullInt64
+	)
+
+	s.Require().Eventually(func() bool {
+		err := s.clickhouseStore.DB().QueryRowContext(context.Background(), `
+SELECT name, prev_name, prev_priority, client_ip, user_agent
+FROM goods_logs
+WHERE operation = 'update' AND request_id = 'audit-0'`,
+		).Scan(&name, &prevName, &prevPriority, &clientIP, &userAgent)
+
+		return err == nil
+	}, 5*time.Second, 100*time.Millisecond)
+
+	s.Require().Equal("renamed_0", name)
+	s.Require().Equal("audited", prevName.String)
+	s.Require().True(prevPriority.Valid)
+	s.Require().Equal(int64(createdGood.Priority), prevPriority.Int64)
+	s.Require().Equal("203.0.113.7", clientIP)
+	s.Require().Equal("audit-test", userAgent)
+}
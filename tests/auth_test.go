@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *IntegrationTestSuite) TestAPIKeys() {
+	var secondProjectID int
+
+	err := s.db.GetTXFromContext(context.Background()).
+		QueryRow(context.Background(), `INSERT INTO projects (name) VALUES ('second') RETURNING id`).
+		Scan(&secondProjectID)
+	s.Require().NoError(err)
+
+	defer func() {
+		_, err := s.db.Exec(context.Background(), `DELETE FROM goods WHERE project_id = $1`, secondProjectID)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(context.Background(), `DELETE FROM projects WHERE id = $1`, secondProjectID)
+		s.Require().NoError(err)
+	}()
+
+	var readKey entity.APIKey
+
+	s.sendAuthRequest(http.MethodPost, "/api/v1/admin/apikey/create", adminAPIKey, http.StatusCreated,
+		&entity.APIKeyCreateRequest{
+			Name:       "reader",
+			ProjectIDs: []int{1},
+			Scopes:     []string{entity.ScopeGoodsRead},
+		}, &readKey)
+	s.Require().NotEmpty(readKey.Key)
+
+	var writeKey entity.APIKey
+
+	s.sendAuthRequest(http.MethodPost, "/api/v1/admin/apikey/create", adminAPIKey, http.StatusCreated,
+		&entity.APIKeyCreateRequest{
+			Name:       "writer",
+			ProjectIDs: []int{1},
+			Scopes:     []string{entity.ScopeGoodsWrite},
+		}, &writeKey)
+
+	var createdGood entity.Good
+
+	s.sendAuthRequest(http.MethodPost, "/api/v1/good/create?projectId=1", writeKey.Key, http.StatusCreated,
+		&entity.GoodCreateRequest{Name: "protected"}, &createdGood)
+
+	getPath := fmt.Sprintf("/api/v1/good/get?id=%d&projectId=1", createdGood.ID)
+
+	s.Run("request without api key", func() {
+		s.sendAuthRequest(http.MethodGet, getPath, "", http.StatusUnauthorized, nil, nil)
+	})
+
+	s.Run("request with unknown api key", func() {
+		s.sendAuthRequest(http.MethodGet, getPath, "hzl_unknown", http.StatusUnauthorized, nil, nil)
+	})
+
+	s.Run("read key can read its project", func() {
+		s.sendAuthRequest(http.MethodGet, getPath, readKey.Key, http.StatusOK, nil, nil)
+		s.sendAuthRequest(http.MethodGet, "/api/v1/goods/list?projectId=1", readKey.Key, http.StatusOK, nil, nil)
+	})
+
+	s.Run("read key can't write", func() {
+		s.sendAuthRequest(http.MethodPost, "/api/v1/good/create?projectId=1", readKey.Key, http.StatusForbidden,
+			&entity.GoodCreateRequest{Name: "forbidden"}, nil)
+	})
+
+	s.Run("keys can't access other projects", func() {
+		s.sendAuthRequest(http.MethodPost, fmt.Sprintf("/api/v1/good/create?projectId=%d", secondProjectID),
+			writeKey.Key, http.StatusForbidden, &entity.GoodCreateRequest{Name: "other project"}, nil)
+		s.sendAuthRequest(http.MethodGet, "/api/v1/goods/list", readKey.Key, http.StatusForbidden, nil, nil)
+	})
+
+	s.Run("only admin keys manage keys", func() {
+		s.sendAuthRequest(http.MethodGet, "/api/v1/admin/apikeys/list", writeKey.Key, http.StatusForbidden, nil, nil)
+
+		var response entity.APIKeysListResponse
+
+		s.sendAuthRequest(http.MethodGet, "/api/v1/admin/apikeys/list", adminAPIKey, http.StatusOK, nil, &response)
+		s.Require().Len(response.APIKeys, 2)
+		s.Require().Empty(response.APIKeys[0].Key)
+	})
+
+	s.Run("rotate key", func() {
+		var rotated entity.APIKey
+
+		s.sendAuthRequest(http.MethodPatch, fmt.Sprintf("/api/v1/admin/apikey/rotate?id=%d", readKey.ID),
+			adminAPIKey, http.StatusOK, nil, &rotated)
+		s.Require().NotEqual(readKey.Key, rotated.Key)
+
+		s.sendAuthRequest(http.MethodGet, getPath, readKey.Key, http.StatusUnauthorized, nil, nil)
+		s.sendAuthRequest(http.MethodGet, getPath, rotated.Key, http.StatusOK, nil, nil)
+
+		readKey = rotated
+	})
+
+	s.Run("keys need a unique name, scopes and projects", func() {
+		s.sendAuthRequest(http.MethodPost, "/api/v1/admin/apikey/create", adminAPIKey, http.StatusConflict,
+			&entity.APIKeyCreateRequest{Name: "writer", ProjectIDs: []int{1}, Scopes: []string{entity.ScopeGoodsRead}}, nil)
+		s.sendAuthRequest(http.MethodPost, "/api/v1/admin/apikey/create", adminAPIKey, http.StatusBadRequest,
+			&entity.APIKeyCreateRequest{Name: "no scopes", ProjectIDs: []int{1}}, nil)
+		s.sendAuthRequest(http.MethodPost, "/api/v1/admin/apikey/create", adminAPIKey, http.StatusBadRequest,
+			&entity.APIKeyCreateRequest{Name: "no projects", Scopes: []string{entity.ScopeGoodsRead}}, nil)
+	})
+
+	s.Run("revoke key", func() {
+		s.sendAuthRequest(http.MethodDelete, fmt.Sprintf("/api/v1/admin/apikey/revoke?id=%d", readKey.ID),
+			adminAPIKey, http.StatusNoContent, nil, nil)
+
+		s.sendAuthRequest(http.MethodGet, getPath, readKey.Key, http.StatusUnauthorized, nil, nil)
+	})
+}
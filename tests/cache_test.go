@@ -0,0 +1,108 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	goodsservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/goods-service"
+)
+
+func (s *IntegrationTestSuite) TestCachePolicy() {
+	ctx := entity.ContextWithSystem(context.Background())
+
+	var createdGood entity.Good
+
+	s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+		&entity.GoodCreateRequest{Name: "cached"}, &createdGood)
+
+	pathGet := goodsPath + fmt.Sprintf("/get?id=%d&projectId=%d", createdGood.ID, createdGood.ProjectID)
+	cacheKey := fmt.Sprintf("good:%d:%d", createdGood.ID, createdGood.ProjectID)
+
+	s.Run("missing good is cached with its own TTL", func() {
+		missingKey := fmt.Sprintf("good:%d:%d", createdGood.ID+1000, createdGood.ProjectID)
+
+		_, err := s.goodsservice.GetGood(ctx, createdGood.ID+1000, createdGood.ProjectID)
+		s.Require().ErrorIs(err, entity.ErrGoodNotFound)
+
+		cached, err := s.redisClient.Get(ctx, missingKey)
+		s.Require().NoError(err)
+		s.Require().Equal("not-found", cached)
+
+		ttl, err := s.redisClient.TTL(ctx, missingKey)
+		s.Require().NoError(err)
+		s.Require().LessOrEqual(ttl, goodsservice.DefaultCacheConfig().NotFoundTTL)
+
+		_, err = s.goodsservice.GetGood(ctx, createdGood.ID+1000, createdGood.ProjectID)
+		s.Require().ErrorIs(err, entity.ErrGoodNotFound)
+	})
+
+	s.Run("TTL is cut by jitter", func() {
+		s.sendRequest(http.MethodGet, pathGet, http.StatusOK, nil, nil)
+
+		ttl, err := s.redisClient.TTL(ctx, cacheKey)
+		s.Require().NoError(err)
+		s.Require().LessOrEqual(ttl, goodsservice.DefaultCacheConfig().GoodTTL)
+		s.Require().Greater(ttl, goodsservice.DefaultCacheConfig().GoodTTL*8/10)
+	})
+
+	s.Run("Cache-Control no-cache reads the database and caches the fresh value", func() {
+		stale := createdGood
+		stale.Name = "stale"
+
+		data, err := json.Marshal(stale)
+		s.Require().NoError(err)
+		s.Require().NoError(s.redisClient.Set(ctx, cacheKey, data, time.Minute))
+
+		var good entity.Good
+
+		s.sendRequest(http.MethodGet, pathGet, http.StatusOK, nil, &good)
+		s.Require().Equal("stale", good.Name)
+
+		s.sendRequestWithHeaders(port, http.MethodGet, pathGet, map[string]string{"Cache-Control": "max-age=0, no-cache"},
+			http.StatusOK, nil, &good)
+		s.Require().Equal("cached", good.Name)
+
+		s.sendRequest(http.MethodGet, pathGet, http.StatusOK, nil, &good)
+		s.Require().Equal("cached", good.Name)
+	})
+
+	s.Run("values over the max size are not cached", func() {
+		service := goodsservice.New(s.goodsrepo, s.natsProducer, s.redisClient)
+		service.SetCacheConfig(goodsservice.CacheConfig{GoodTTL: time.Minute, ListTTL: time.Minute, MaxValueSize: 10})
+
+		s.Require().NoError(s.redisClient.Del(ctx, cacheKey))
+
+		_, err := service.GetGood(ctx, createdGood.ID, createdGood.ProjectID)
+		s.Require().NoError(err)
+
+		_, err = s.redisClient.Get(ctx, cacheKey)
+		s.Require().ErrorIs(err, redis.Nil)
+	})
+
+	s.Run("changes invalidate the lists of the project", func() {
+		listKey := fmt.Sprintf("goods:list:%d:10:0", createdGood.ProjectID)
+
+		var list entity.GoodsListResponse
+
+		s.sendRequest(http.MethodGet, "/api/v1/goods/list?projectId=1&limit=10&offset=0", http.StatusOK, nil, &list)
+
+		_, err := s.redisClient.Get(ctx, listKey)
+		s.Require().NoError(err)
+
+		var newGood entity.Good
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: "new"}, &newGood)
+
+		_, err = s.redisClient.Get(ctx, listKey)
+		s.Require().ErrorIs(err, redis.Nil)
+
+		s.sendRequest(http.MethodGet, "/api/v1/goods/list?projectId=1&limit=10&offset=0", http.StatusOK, nil, &list)
+		s.Require().Len(list.Goods, 2)
+	})
+}
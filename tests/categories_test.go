@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	goodsrepo "github.com/romanpitatelev/hezzl-goods/internal/repository/goods-repo"
+	goodsservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/goods-service"
+)
+
+func (s *IntegrationTestSuite) TestCategories() {
+	createCategory := func(name string, parentID *int) entity.Category {
+		var category entity.Category
+
+		s.sendRequest(http.MethodPost, "/api/v1/category/create?projectId=1", http.StatusCreated,
+			&entity.CategoryCreateRequest{Name: name, ParentID: parentID}, &category)
+
+		return category
+	}
+
+	createGood := func(name string, categoryID *int) entity.Good {
+		var good entity.Good
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: name, CategoryID: categoryID}, &good)
+
+		return good
+	}
+
+	listByCategory := func(categoryID int) []string {
+		var list entity.GoodsListResponse
+
+		s.sendRequest(http.MethodGet, fmt.Sprintf("/api/v1/goods/list?projectId=1&categoryId=%d", categoryID),
+			http.StatusOK, nil, &list)
+
+		names := make([]string, 0, len(list.Goods))
+		for _, good := range list.Goods {
+			names = append(names, good.Name)
+		}
+
+		return names
+	}
+
+	clothes := createCategory("clothes", nil)
+	shoes := createCategory("shoes", &clothes.ID)
+	boots := createCategory("boots", &shoes.ID)
+	food := createCategory("food", nil)
+
+	shirt := createGood("shirt", &clothes.ID)
+	sneakers := createGood("sneakers", &shoes.ID)
+	createGood("wellingtons", &boots.ID)
+	createGood("bread", &food.ID)
+
+	s.Run("categories are listed as a tree", func() {
+		s.Require().Equal(fmt.Sprintf("/%d/%d/%d/", clothes.ID, shoes.ID, boots.ID), boots.Path)
+
+		var response entity.CategoriesResponse
+
+		s.sendRequest(http.MethodGet, "/api/v1/categories/list?projectId=1", http.StatusOK, nil, &response)
+		s.Require().Len(response.Categories, 4)
+		s.Require().Equal(clothes.ID, response.Categories[0].ID)
+		s.Require().Equal(shoes.ID, response.Categories[1].ID)
+		s.Require().Equal(boots.ID, response.Categories[2].ID)
+	})
+
+	s.Run("sibling names are unique", func() {
+		s.sendRequest(http.MethodPost, "/api/v1/category/create?projectId=1", http.StatusConflict,
+			&entity.CategoryCreateRequest{Name: "shoes", ParentID: &clothes.ID}, nil)
+		s.sendRequest(http.MethodPatch, fmt.Sprintf("/api/v1/category/rename?id=%d&projectId=1", food.ID),
+			http.StatusConflict, &entity.CategoryRenameRequest{Name: "clothes"}, nil)
+
+		createCategory("shoes", &food.ID)
+	})
+
+	s.Run("categories of other projects are not found", func() {
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=2", http.StatusNotFound,
+			&entity.GoodCreateRequest{Name: "foreign", CategoryID: &clothes.ID}, nil)
+	})
+
+	s.Run("list includes goods of subcategories", func() {
+		s.Require().ElementsMatch([]string{"shirt", "sneakers", "wellingtons"}, listByCategory(clothes.ID))
+		s.Require().ElementsMatch([]string{"sneakers", "wellingtons"}, listByCategory(shoes.ID))
+		s.Require().ElementsMatch([]string{"bread"}, listByCategory(food.ID))
+	})
+
+	s.Run("move carries the subtree and rejects cycles", func() {
+		s.sendRequest(http.MethodPatch, fmt.Sprintf("/api/v1/category/move?id=%d&projectId=1", clothes.ID),
+			http.StatusBadRequest, &entity.CategoryMoveRequest{ParentID: &boots.ID}, nil)
+
+		var moved entity.Category
+
+		s.sendRequest(http.MethodPatch, fmt.Sprintf("/api/v1/category/move?id=%d&projectId=1", shoes.ID),
+			http.StatusOK, &entity.CategoryMoveRequest{ParentID: nil}, &moved)
+		s.Require().Nil(moved.ParentID)
+		s.Require().Equal(fmt.Sprintf("/%d/", shoes.ID), moved.Path)
+
+		s.Require().ElementsMatch([]string{"shirt"}, listByCategory(clothes.ID))
+		s.Require().ElementsMatch([]string{"sneakers", "wellingtons"}, listByCategory(shoes.ID))
+	})
+
+	s.Run("good category is set and unset", func() {
+		var good entity.Good
+
+		s.sendRequest(http.MethodPatch, fmt.Sprintf("/api/v1/good/category?id=%d&projectId=1", shirt.ID),
+			http.StatusOK, &entity.GoodCategoryRequest{CategoryID: &food.ID}, &good)
+		s.Require().Equal(food.ID, *good.CategoryID)
+		s.Require().ElementsMatch([]string{"shirt", "bread"}, listByCategory(food.ID))
+
+		s.sendRequest(http.MethodPatch, fmt.Sprintf("/api/v1/good/category?id=%d&projectId=1", shirt.ID),
+			http.StatusOK, &entity.GoodCategoryRequest{}, &good)
+		s.Require().Nil(good.CategoryID)
+	})
+
+	s.Run("delete moves goods to the parent", func() {
+		var response entity.CategoryDeleteResponse
+
+		s.sendRequest(http.MethodPatch, fmt.Sprintf("/api/v1/category/move?id=%d&projectId=1", shoes.ID),
+			http.StatusOK, &entity.CategoryMoveRequest{ParentID: &clothes.ID}, nil)
+		s.sendRequest(http.MethodDelete, fmt.Sprintf("/api/v1/category/remove?id=%d&projectId=1", shoes.ID),
+			http.StatusOK, nil, &response)
+		s.Require().Equal(entity.CategoryDeleteResponse{Deleted: 2, MovedGoods: 2}, response)
+
+		var good entity.Good
+
+		s.sendRequest(http.MethodGet, goodsPath+fmt.Sprintf("/get?id=%d&projectId=1", sneakers.ID), http.StatusOK, nil, &good)
+		s.Require().Equal(clothes.ID, *good.CategoryID)
+		s.Require().ElementsMatch([]string{"sneakers", "wellingtons"}, listByCategory(clothes.ID))
+
+		s.sendRequest(http.MethodDelete, fmt.Sprintf("/api/v1/category/remove?id=%d&projectId=1", shoes.ID),
+			http.StatusNotFound, nil, nil)
+	})
+
+	s.Run("priority can be scoped per category", func() {
+		ctx := entity.ContextWithSystem(context.Background())
+		service := goodsservice.New(
+			goodsrepo.New(goodsrepo.Config{PriorityScope: entity.PriorityScopeCategory}, s.db),
+			s.natsProducer, s.redisClient,
+		)
+
+		first, err := service.CreateGood(ctx, 1, entity.GoodCreateRequest{Name: "first", CategoryID: &food.ID})
+		s.Require().NoError(err)
+		second, err := service.CreateGood(ctx, 1, entity.GoodCreateRequest{Name: "second", CategoryID: &food.ID})
+		s.Require().NoError(err)
+		s.Require().Equal(first.Priority+1, second.Priority)
+
+		other, err := service.CreateGood(ctx, 1, entity.GoodCreateRequest{Name: "other", CategoryID: &clothes.ID})
+		s.Require().NoError(err)
+		s.Require().Equal(4, other.Priority)
+
+		response, err := service.Reprioritize(ctx, second.ID, 1, entity.PriorityRequest{NewPriority: first.Priority})
+		s.Require().NoError(err)
+		s.Require().Len(response.Priorities, 2)
+	})
+}
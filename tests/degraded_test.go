@@ -0,0 +1,178 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/nats/producer"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/clickhouse"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/redis"
+	goodsservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/goods-service"
+	healthservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/health-service"
+)
+
+const unreachableAddr = "localhost:1"
+
+func (s *IntegrationTestSuite) TestDegradedMode() {
+	ctx, cancel := context.WithCancel(entity.ContextWithSystem(context.Background()))
+	defer cancel()
+
+	s.Run("without Redis", func() {
+		redisClient := redis.Connect(unreachableAddr, redisPassword, redisDB)
+		s.Require().Error(redisClient.Ping(ctx))
+		s.Require().ErrorIs(redisClient.Set(ctx, "degraded", "value", time.Minute), redis.ErrUnavailable)
+
+		service := goodsservice.New(s.goodsrepo, s.natsProducer, redisClient)
+
+		createdGood, err := service.CreateGood(ctx, 1, entity.GoodCreateRequest{Name: "without redis"})
+		s.Require().NoError(err)
+
+		good, err := service.GetGood(ctx, createdGood.ID, createdGood.ProjectID)
+		s.Require().NoError(err)
+		s.Require().Equal(createdGood.Name, good.Name)
+
+		_, err = service.GetGoods(ctx, entity.ListRequest{ProjectID: 1})
+		s.Require().NoError(err)
+
+		s.requireDegraded(ctx, "redis", redisClient)
+	})
+
+	s.Run("without ClickHouse", func() {
+		store, err := clickhouse.Open(clickhouse.Config{Dsn: "clickhouse://user:my_pass@" + unreachableAddr + "/hezzl_logs"})
+		s.Require().NoError(err)
+
+		defer func() {
+			s.Require().NoError(store.Close())
+		}()
+
+		s.requireDegraded(ctx, "clickhouse", store)
+	})
+
+	s.Run("without NATS events are buffered until it is back", func() {
+		listener, err := net.Listen("tcp", "localhost:0")
+		s.Require().NoError(err)
+
+		proxyAddr := listener.Addr().String()
+		s.Require().NoError(listener.Close())
+
+		nc, err := nats.Connect("nats://"+proxyAddr,
+			nats.RetryOnFailedConnect(true),
+			nats.MaxReconnects(-1),
+			nats.ReconnectWait(50*time.Millisecond),
+		)
+		s.Require().NoError(err)
+
+		defer nc.Close()
+
+		s.Require().False(nc.IsConnected())
+
+		natsProducer := producer.New(ctx, nc, "goods.degraded")
+
+		s.requireDegraded(ctx, "nats", natsProducer)
+
+		subscriber, err := nats.Connect(natsURL)
+		s.Require().NoError(err)
+
+		defer subscriber.Close()
+
+		received := make(chan *nats.Msg, 10)
+
+		_, err = subscriber.ChanSubscribe("goods.degraded", received)
+		s.Require().NoError(err)
+		s.Require().NoError(subscriber.Flush())
+
+		for i := range 5 {
+			s.Require().NoError(natsProducer.Publish(ctx, "goods.degraded", entity.GoodLog{GoodID: i}))
+		}
+
+		s.Require().Equal(5, natsProducer.Buffered())
+
+		s.startProxy(ctx, proxyAddr, "localhost:4222")
+
+		for i := range 5 {
+			select {
+			case msg := <-received:
+				var logMsg entity.GoodLog
+
+				s.Require().NoError(json.Unmarshal(msg.Data, &logMsg))
+				s.Require().Equal(i, logMsg.GoodID)
+			case <-time.After(5 * time.Second):
+				s.FailNow("buffered event was not published")
+			}
+		}
+
+		s.Require().Zero(natsProducer.Buffered())
+	})
+}
+
+// requireDegraded checks that readiness is degraded, not down, while the optional dependency is unavailable.
+func (s *IntegrationTestSuite) requireDegraded(ctx context.Context, name string, dependency interface {
+	Ping(ctx context.Context) error
+},
+) {
+	service := healthservice.New(healthservice.Config{Timeout: time.Second})
+	service.AddDependency("postgres", s.db)
+	service.AddOptionalDependency(name, dependency)
+
+	report := service.Readiness(ctx)
+
+	s.Require().Equal(entity.HealthStatusDegraded, report.Status)
+	s.Require().True(report.Ready())
+	s.Require().Equal(entity.HealthStatusOK, report.Checks["postgres"].Status)
+	s.Require().Equal(entity.HealthStatusDown, report.Checks[name].Status)
+	s.Require().True(report.Checks[name].Optional)
+}
+
+// startProxy forwards TCP connections from addr to target until ctx is done.
+func (s *IntegrationTestSuite) startProxy(ctx context.Context, addr, target string) {
+	var listenConfig net.ListenConfig
+
+	listener, err := listenConfig.Listen(ctx, "tcp", addr)
+	s.Require().NoError(err)
+
+	go func() {
+		<-ctx.Done()
+
+		_ = listener.Close()
+	}()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			if err != nil {
+				continue
+			}
+
+			go proxy(ctx, conn, target)
+		}
+	}()
+}
+
+func proxy(ctx context.Context, conn net.Conn, target string) {
+	defer conn.Close()
+
+	var dialer net.Dialer
+
+	upstream, err := dialer.DialContext(ctx, "tcp", target)
+	if err != nil {
+		return
+	}
+
+	defer upstream.Close()
+
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+	}()
+
+	_, _ = io.Copy(conn, upstream)
+}
@@ -0,0 +1,214 @@
+package tests
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/auth"
+	exporthandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/export-handler"
+	ratelimit "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/rate-limit"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	goodsexport "github.com/romanpitatelev/hezzl-goods/internal/usecase/goods-export"
+)
+
+func (s *IntegrationTestSuite) TestExport() {
+	ctx := entity.ContextWithSystem(context.Background())
+
+	var otherProjectID int
+
+	err := s.db.GetTXFromContext(ctx).
+		QueryRow(ctx, `INSERT INTO projects (name) VALUES ('other') RETURNING id`).
+		Scan(&otherProjectID)
+	s.Require().NoError(err)
+
+	defer func() {
+		_, err := s.db.Exec(ctx, `DELETE FROM goods WHERE project_id = $1`, otherProjectID)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(ctx, `DELETE FROM projects WHERE id = $1`, otherProjectID)
+		s.Require().NoError(err)
+	}()
+
+	export := func(query string) (http.Header, string) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			fmt.Sprintf("http://localhost:%d/api/v1/goods/export?%s", port, query), nil)
+		s.Require().NoError(err)
+
+		response, err := http.DefaultClient.Do(request)
+		s.Require().NoError(err)
+
+		defer func() {
+			s.Require().NoError(response.Body.Close())
+		}()
+
+		body, err := io.ReadAll(response.Body)
+		s.Require().NoError(err)
+		s.Require().Equal(http.StatusOK, response.StatusCode, string(body))
+
+		return response.Header, string(body)
+	}
+
+	description := "Cotton, \"light\"\nfor summer"
+
+	var first, second, third entity.Good
+
+	s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+		&entity.GoodCreateRequest{Name: "Dress", Description: &description, Tags: []string{"summer", "promo"}}, &first)
+	s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+		&entity.GoodCreateRequest{Name: "Coat", Tags: []string{"winter"}}, &second)
+	s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+		&entity.GoodCreateRequest{Name: "Hat", Tags: []string{"summer"}}, &third)
+	s.sendRequest(http.MethodPost, goodsPath+fmt.Sprintf("/create?projectId=%d", otherProjectID), http.StatusCreated,
+		&entity.GoodCreateRequest{Name: "Other project"}, nil)
+
+	s.sendRequest(http.MethodPatch, goodsPath+fmt.Sprintf("/reprioritize?id=%d&projectId=1", first.ID), http.StatusOK,
+		&entity.PriorityRequest{NewPriority: 100}, nil)
+
+	s.Run("csv in priority order", func() {
+		header, body := export("projectId=1")
+
+		s.Require().Equal("text/csv; charset=utf-8", header.Get("Content-Type"))
+		s.Require().Equal(`attachment; filename="goods-1.csv"`, header.Get("Content-Disposition"))
+
+		records, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+		s.Require().NoError(err)
+		s.Require().Len(records, 4)
+
+		s.Require().Equal([]string{
+			"id", "project_id", "external_id", "name", "description", "priority", "removed", "tags", "category_id", "attributes", "created_at",
+		}, records[0])
+		s.Require().Equal([]string{"Coat", "Hat", "Dress"}, []string{records[1][3], records[2][3], records[3][3]})
+
+		s.Require().Equal(description, records[3][4])
+		s.Require().Equal("100", records[3][5])
+		s.Require().Equal("promo,summer", records[3][7])
+		s.Require().Equal("{}", records[3][9])
+	})
+
+	s.Run("jsonl", func() {
+		header, body := export("projectId=1&format=jsonl")
+
+		s.Require().Equal("application/x-ndjson", header.Get("Content-Type"))
+		s.Require().Equal(`attachment; filename="goods-1.jsonl"`, header.Get("Content-Disposition"))
+
+		var ids []int
+
+		scanner := bufio.NewScanner(strings.NewReader(body))
+		for scanner.Scan() {
+			var good entity.Good
+			s.Require().NoError(json.Unmarshal(scanner.Bytes(), &good))
+			s.Require().Equal(1, good.ProjectID)
+
+			ids = append(ids, good.ID)
+		}
+
+		s.Require().Equal([]int{second.ID, third.ID, first.ID}, ids)
+	})
+
+	s.Run("filters of the list", func() {
+		_, body := export("projectId=1&format=jsonl&tags=summer")
+		s.Require().Equal(2, strings.Count(body, "\n"))
+
+		_, body = export("projectId=1&format=jsonl&tags=summer,promo&tagMode=all")
+		s.Require().Equal(1, strings.Count(body, "\n"))
+		s.Require().Contains(body, `"name":"Dress"`)
+	})
+
+	s.Run("empty export has the header", func() {
+		_, body := export(fmt.Sprintf("projectId=%d", otherProjectID+1))
+
+		s.Require().Equal("id,project_id,external_id,name,description,priority,removed,tags,category_id,attributes,created_at\n", body)
+	})
+
+	s.Run("invalid exports are rejected", func() {
+		s.sendRequest(http.MethodGet, "/api/v1/goods/export?projectId=1&format=xml", http.StatusBadRequest, nil, nil)
+		s.sendRequest(http.MethodGet, "/api/v1/goods/export?format=csv", http.StatusBadRequest, nil, nil)
+		s.sendRequest(http.MethodGet, "/api/v1/goods/export?projectId=1&attr.color=eq:red", http.StatusBadRequest, nil, nil)
+	})
+
+	s.Run("exporter writes to a file", func() {
+		var buf bytes.Buffer
+
+		exported, err := s.exporter.Export(ctx, entity.ExportRequest{
+			Format: entity.FormatJSONL,
+			Filter: entity.ListRequest{ProjectID: otherProjectID},
+		}, &buf)
+		s.Require().NoError(err)
+		s.Require().Equal(1, exported)
+		s.Require().Contains(buf.String(), `"name":"Other project"`)
+	})
+	s.Run("failed export aborts the response", func() {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		server := rest.New(
+			rest.Config{BindAddress: fmt.Sprintf(":%d", exportPort)},
+			s.goodshandler,
+			s.streamhandler,
+			exporthandler.New(goodsexport.New(failingGoodsStore{goods: 100})),
+			s.webhookshandler,
+			s.apikeyshandler,
+			auth.New(auth.Config{}, nil, nil),
+			ratelimit.New(ratelimit.Config{}, nil),
+			s.healthhandler,
+		)
+
+		stopped := make(chan error, 1)
+
+		go func() {
+			stopped <- server.Run(ctx)
+		}()
+
+		s.Require().Eventually(func() bool {
+			return s.probe(exportPort) == http.StatusOK
+		}, time.Second, 10*time.Millisecond)
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet,
+			fmt.Sprintf("http://localhost:%d/api/v1/goods/export?projectId=1", exportPort), nil)
+		s.Require().NoError(err)
+
+		response, err := http.DefaultClient.Do(request)
+		s.Require().NoError(err)
+
+		defer func() {
+			_ = response.Body.Close()
+		}()
+
+		s.Require().Equal(http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+		s.Require().Error(err)
+		s.Require().NotEmpty(body)
+
+		cancel()
+		s.Require().NoError(<-stopped)
+	})
+}
+
+// failingGoodsStore exports goods and then fails like a broken cursor.
+type failingGoodsStore struct {
+	goods int
+}
+
+func (f failingGoodsStore) ExportGoods(_ context.Context, request entity.ListRequest, fn func(entity.Good) error) error {
+	for id := range f.goods {
+		if err := fn(entity.Good{ID: id + 1, ProjectID: request.ProjectID, Name: strings.Repeat("good", 50)}); err != nil {
+			return err
+		}
+	}
+
+	return errors.New("connection reset by peer") //nolint:err113
+}
+
+func (f failingGoodsStore) GetAttributeSchema(_ context.Context, projectID int) (entity.AttributeSchema, error) {
+	return entity.AttributeSchema{ProjectID: projectID}, nil
+}
@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *IntegrationTestSuite) TestExternalID() {
+	externalID := "ERP-1"
+
+	importGoods := func(query, body string) entity.ImportResponse {
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+			fmt.Sprintf("http://localhost:%d/api/v1/goods/import?%s", port, query), strings.NewReader(body))
+		s.Require().NoError(err)
+
+		response, err := http.DefaultClient.Do(request)
+		s.Require().NoError(err)
+
+		defer func() {
+			s.Require().NoError(response.Body.Close())
+		}()
+
+		s.Require().Equal(http.StatusOK, response.StatusCode)
+
+		var result entity.ImportResponse
+		s.Require().NoError(json.NewDecoder(response.Body).Decode(&result))
+
+		return result
+	}
+
+	s.Run("create with external id", func() {
+		var good entity.Good
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{ExternalID: &externalID, Name: "Milk"}, &good)
+		s.Require().NotNil(good.ExternalID)
+		s.Require().Equal(externalID, *good.ExternalID)
+
+		var found entity.Good
+
+		s.sendRequest(http.MethodGet, goodsPath+"/by-external-id?projectId=1&externalId=ERP-1", http.StatusOK, nil, &found)
+		s.Require().Equal(good.ID, found.ID)
+	})
+
+	s.Run("used external id is a conflict", func() {
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusConflict,
+			&entity.GoodCreateRequest{ExternalID: &externalID, Name: "Other milk"}, nil)
+	})
+
+	s.Run("unknown external id is not found", func() {
+		s.sendRequest(http.MethodGet, goodsPath+"/by-external-id?projectId=1&externalId=ERP-404", http.StatusNotFound, nil, nil)
+	})
+
+	s.Run("invalid external ids are rejected", func() {
+		blank := "  "
+		long := strings.Repeat("x", 129)
+
+		s.sendRequest(http.MethodGet, goodsPath+"/by-external-id?projectId=1", http.StatusBadRequest, nil, nil)
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusBadRequest,
+			&entity.GoodCreateRequest{ExternalID: &blank, Name: "Blank"}, nil)
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusBadRequest,
+			&entity.GoodCreateRequest{ExternalID: &long, Name: "Long"}, nil)
+		s.sendRequest(http.MethodPut, goodsPath+"/upsert?projectId=1", http.StatusBadRequest,
+			&entity.GoodCreateRequest{Name: "No external id"}, nil)
+	})
+
+	s.Run("upsert creates and then updates", func() {
+		upserted := "ERP-2"
+		tags := []string{"dairy"}
+
+		var created entity.Good
+
+		s.sendRequest(http.MethodPut, goodsPath+"/upsert?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{ExternalID: &upserted, Name: "Cheese"}, &created)
+		s.Require().Equal("Cheese", created.Name)
+
+		var updated entity.Good
+
+		s.sendRequest(http.MethodPut, goodsPath+"/upsert?projectId=1", http.StatusOK,
+			&entity.GoodCreateRequest{ExternalID: &upserted, Name: "Blue cheese", Tags: tags}, &updated)
+		s.Require().Equal(created.ID, updated.ID)
+		s.Require().Equal(created.Priority, updated.Priority)
+		s.Require().Equal("Blue cheese", updated.Name)
+		s.Require().Equal(tags, updated.Tags)
+	})
+
+	s.Run("import upserts by external id", func() {
+		var before entity.Good
+
+		s.sendRequest(http.MethodGet, goodsPath+"/by-external-id?projectId=1&externalId=ERP-1", http.StatusOK, nil, &before)
+
+		response := importGoods("projectId=1&mode=upsert", "external_id,name\nERP-1,Skimmed milk\nERP-3,Butter\nERP-3,Cream\n")
+		s.Require().Equal(1, response.Created)
+		s.Require().Equal(1, response.Updated)
+		s.Require().Equal(1, response.Failed)
+		s.Require().Equal(4, response.Errors[0].Line)
+
+		var after entity.Good
+
+		s.sendRequest(http.MethodGet, goodsPath+"/by-external-id?projectId=1&externalId=ERP-1", http.StatusOK, nil, &after)
+		s.Require().Equal(before.ID, after.ID)
+		s.Require().Equal("Skimmed milk", after.Name)
+
+		response = importGoods("projectId=1", "external_id,name\nERP-3,Margarine\n")
+		s.Require().Equal([]entity.ImportError{{Line: 2, Error: entity.ErrExternalIDExists.Error()}}, response.Errors)
+	})
+	s.Run("reprioritize and delete events carry the external id", func() {
+		subscriber, err := nats.Connect(natsURL)
+		s.Require().NoError(err)
+
+		defer subscriber.Close()
+
+		received := make(chan *nats.Msg, 1000)
+
+		_, err = subscriber.ChanSubscribe("goods.logs", received)
+		s.Require().NoError(err)
+		s.Require().NoError(subscriber.Flush())
+
+		var good entity.Good
+
+		s.sendRequest(http.MethodGet, goodsPath+"/by-external-id?projectId=1&externalId=ERP-1", http.StatusOK, nil, &good)
+
+		s.sendRequest(http.MethodPatch, goodsPath+fmt.Sprintf("/reprioritize?id=%d&projectId=1", good.ID), http.StatusOK,
+			&entity.PriorityRequest{NewPriority: good.Priority + 100}, nil)
+		s.sendRequest(http.MethodDelete, goodsPath+fmt.Sprintf("/remove?id=%d&projectId=1", good.ID), http.StatusOK, nil, nil)
+
+		externalIDs := map[string]*string{}
+
+		for len(externalIDs) < 2 {
+			select {
+			case msg := <-received:
+				var logMsg entity.GoodLog
+				s.Require().NoError(json.Unmarshal(msg.Data, &logMsg))
+
+				if logMsg.GoodID == good.ID {
+					externalIDs[logMsg.Operation] = logMsg.ExternalID
+				}
+			case <-time.After(5 * time.Second):
+				s.FailNow("events are not published", "%v", externalIDs)
+			}
+		}
+
+		for _, operation := range []string{"reprioritize", "delete"} {
+			s.Require().NotNil(externalIDs[operation], operation)
+			s.Require().Equal(externalID, *externalIDs[operation], operation)
+		}
+	})
+}
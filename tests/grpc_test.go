@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	goodsv1 "github.com/romanpitatelev/hezzl-goods/api/goods/v1"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func (s *IntegrationTestSuite) TestGRPCGoods() {
+	ctx := entity.ContextWithSystem(context.Background())
+
+	description := "grpc description"
+
+	createdGood, err := s.grpcClient.CreateGood(ctx, &goodsv1.CreateGoodRequest{
+		ProjectId:   1,
+		Name:        "grpc good",
+		Description: &description,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("grpc good", createdGood.GetName())
+	s.Require().Equal(description, createdGood.GetDescription())
+
+	s.Run("get good", func() {
+		good, err := s.grpcClient.GetGood(ctx, &goodsv1.GetGoodRequest{
+			Id:        createdGood.GetId(),
+			ProjectId: createdGood.GetProjectId(),
+		})
+		s.Require().NoError(err)
+		s.Require().Equal(createdGood.GetId(), good.GetId())
+	})
+
+	s.Run("get good not found", func() {
+		_, err := s.grpcClient.GetGood(ctx, &goodsv1.GetGoodRequest{
+			Id:        9999,
+			ProjectId: createdGood.GetProjectId(),
+		})
+		s.Require().Equal(codes.NotFound, status.Code(err))
+	})
+
+	s.Run("update good", func() {
+		good, err := s.grpcClient.UpdateGood(ctx, &goodsv1.UpdateGoodRequest{
+			Id:        createdGood.GetId(),
+			ProjectId: createdGood.GetProjectId(),
+			Name:      "grpc updated",
+		})
+		s.Require().NoError(err)
+		s.Require().Equal("grpc updated", good.GetName())
+		s.Require().Equal(description, good.GetDescription())
+	})
+
+	s.Run("update tags and category", func() {
+		good, err := s.grpcClient.UpdateGood(ctx, &goodsv1.UpdateGoodRequest{
+			Id:        createdGood.GetId(),
+			ProjectId: createdGood.GetProjectId(),
+			Name:      "grpc updated",
+			Tags:      &goodsv1.TagList{Tags: []string{"grpc"}},
+		})
+		s.Require().NoError(err)
+		s.Require().Equal([]string{"grpc"}, good.GetTags())
+
+		category, err := s.goodsrepo.CreateCategory(ctx, 1, entity.CategoryCreateRequest{Name: "grpc"})
+		s.Require().NoError(err)
+
+		categoryID := int64(category.ID)
+
+		good, err = s.grpcClient.SetGoodCategory(ctx, &goodsv1.SetGoodCategoryRequest{
+			Id:         createdGood.GetId(),
+			ProjectId:  createdGood.GetProjectId(),
+			CategoryId: &categoryID,
+		})
+		s.Require().NoError(err)
+		s.Require().Equal(categoryID, good.GetCategoryId())
+
+		response, err := s.grpcClient.ListGoods(ctx, &goodsv1.ListGoodsRequest{
+			ProjectId:  1,
+			Limit:      10,
+			Tags:       []string{"grpc"},
+			CategoryId: categoryID,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(response.GetGoods(), 1)
+		s.Require().Equal(createdGood.GetId(), response.GetGoods()[0].GetId())
+	})
+
+	s.Run("create good with empty name", func() {
+		_, err := s.grpcClient.CreateGood(ctx, &goodsv1.CreateGoodRequest{ProjectId: 1})
+		s.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	s.Run("stream goods", func() {
+		var projectID int64
+
+		err := s.db.GetTXFromContext(ctx).
+			QueryRow(ctx, `INSERT INTO projects (name) VALUES ('grpc stream') RETURNING id`).
+			Scan(&projectID)
+		s.Require().NoError(err)
+
+		defer func() {
+			_, err := s.db.Exec(ctx, `DELETE FROM goods WHERE project_id = $1`, projectID)
+			s.Require().NoError(err)
+			_, err = s.db.Exec(ctx, `DELETE FROM projects WHERE id = $1`, projectID)
+			s.Require().NoError(err)
+		}()
+
+		for i := range 5 {
+			_, err := s.grpcClient.CreateGood(ctx, &goodsv1.CreateGoodRequest{
+				ProjectId: projectID,
+				Name:      fmt.Sprintf("grpc_%d", i),
+			})
+			s.Require().NoError(err)
+		}
+
+		stream, err := s.grpcClient.StreamGoods(ctx, &goodsv1.StreamGoodsRequest{ProjectId: projectID, PageSize: 2})
+		s.Require().NoError(err)
+
+		received := 0
+
+		for {
+			good, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			s.Require().NoError(err)
+			s.Require().Equal(projectID, good.GetProjectId())
+
+			received++
+		}
+
+		s.Require().Equal(5, received)
+	})
+
+	s.Run("delete good", func() {
+		response, err := s.grpcClient.DeleteGood(ctx, &goodsv1.DeleteGoodRequest{
+			Id:        createdGood.GetId(),
+			ProjectId: createdGood.GetProjectId(),
+		})
+		s.Require().NoError(err)
+		s.Require().True(response.GetRemoved())
+	})
+}
+
+func (s *IntegrationTestSuite) TestGRPCAuthentication() {
+	var readKey entity.APIKey
+
+	s.sendAuthRequest(http.MethodPost, "/api/v1/admin/apikey/create", adminAPIKey, http.StatusCreated,
+		&entity.APIKeyCreateRequest{
+			Name:       "grpc reader",
+			ProjectIDs: []int{1},
+			Scopes:     []string{entity.ScopeGoodsRead},
+		}, &readKey)
+
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+	}
+
+	s.Run("calls without valid credentials are rejected", func() {
+		_, err := s.grpcAuthClient.ListGoods(context.Background(), &goodsv1.ListGoodsRequest{ProjectId: 1})
+		s.Require().Equal(codes.Unauthenticated, status.Code(err))
+
+		_, err = s.grpcAuthClient.ListGoods(withKey("hzl_unknown"), &goodsv1.ListGoodsRequest{ProjectId: 1})
+		s.Require().Equal(codes.Unauthenticated, status.Code(err))
+	})
+
+	s.Run("scope and project are checked", func() {
+		ctx := withKey(readKey.Key)
+
+		_, err := s.grpcAuthClient.ListGoods(ctx, &goodsv1.ListGoodsRequest{ProjectId: 1})
+		s.Require().NoError(err)
+
+		_, err = s.grpcAuthClient.CreateGood(ctx, &goodsv1.CreateGoodRequest{ProjectId: 1, Name: "forbidden"})
+		s.Require().Equal(codes.PermissionDenied, status.Code(err))
+
+		_, err = s.grpcAuthClient.ListGoods(ctx, &goodsv1.ListGoodsRequest{ProjectId: 2})
+		s.Require().Equal(codes.PermissionDenied, status.Code(err))
+
+		_, err = s.grpcAuthClient.ListGoods(ctx, &goodsv1.ListGoodsRequest{})
+		s.Require().Equal(codes.PermissionDenied, status.Code(err))
+	})
+
+	s.Run("streams are checked", func() {
+		stream, err := s.grpcAuthClient.StreamGoods(withKey(readKey.Key), &goodsv1.StreamGoodsRequest{ProjectId: 2})
+		s.Require().NoError(err)
+
+		_, err = stream.Recv()
+		s.Require().Equal(codes.PermissionDenied, status.Code(err))
+
+		stream, err = s.grpcAuthClient.StreamGoods(withKey(readKey.Key), &goodsv1.StreamGoodsRequest{ProjectId: 1})
+		s.Require().NoError(err)
+
+		for {
+			_, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			s.Require().NoError(err)
+		}
+	})
+
+	s.Run("bearer token creates goods as its subject", func() {
+		token := s.signToken(jwt.SigningMethodRS256, jwt.MapClaims{
+			"sub":      "grpc-writer",
+			"projects": []int{1},
+			"roles":    []string{entity.ScopeGoodsWrite},
+		})
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+
+		_, err := s.grpcAuthClient.CreateGood(ctx, &goodsv1.CreateGoodRequest{ProjectId: 1, Name: "grpc token"})
+		s.Require().NoError(err)
+
+		s.Require().Eventually(func() bool {
+			var count int
+
+			err := s.clickhouseStore.DB().QueryRowContext(context.Background(),
+				"SELECT count() FROM goods_logs WHERE actor = 'jwt:grpc-writer'").Scan(&count)
+
+			return err == nil && count == 1
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+}
@@ -0,0 +1,127 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/auth"
+	healthhandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/health-handler"
+	ratelimit "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/rate-limit"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	healthservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/health-service"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *IntegrationTestSuite) TestHealth() {
+	s.Run("liveness", func() {
+		var report entity.HealthReport
+
+		s.sendRequest(http.MethodGet, "/healthz", http.StatusOK, nil, &report)
+		s.Require().Equal(entity.HealthStatusOK, report.Status)
+	})
+
+	s.Run("readiness checks every dependency", func() {
+		var report entity.HealthReport
+
+		s.sendRequest(http.MethodGet, "/readyz", http.StatusOK, nil, &report)
+		s.Require().Equal(entity.HealthStatusOK, report.Status)
+
+		for _, name := range []string{"postgres", "redis", "nats", "clickhouse"} {
+			s.Require().Equal(entity.HealthStatusOK, report.Checks[name].Status, name)
+		}
+	})
+
+	s.Run("not ready while draining", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		server := rest.New(
+			rest.Config{BindAddress: fmt.Sprintf(":%d", drainPort), DrainDelay: time.Second},
+			s.goodshandler,
+			s.streamhandler,
+			s.exporthandler,
+			s.webhookshandler,
+			s.apikeyshandler,
+			auth.New(auth.Config{}, nil, nil),
+			ratelimit.New(ratelimit.Config{}, nil),
+			s.healthhandler,
+		)
+
+		stopped := make(chan error, 1)
+
+		go func() {
+			stopped <- server.Run(ctx)
+		}()
+
+		s.Require().Eventually(func() bool {
+			return s.probe(drainPort) == http.StatusOK
+		}, time.Second, 10*time.Millisecond)
+
+		cancel()
+
+		s.Require().Eventually(func() bool {
+			return s.probe(drainPort) == http.StatusServiceUnavailable
+		}, time.Second, 10*time.Millisecond)
+
+		s.Require().NoError(<-stopped)
+	})
+}
+
+func (s *IntegrationTestSuite) probe(serverPort int) int {
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+		fmt.Sprintf("http://localhost:%d/readyz", serverPort), nil)
+	s.Require().NoError(err)
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return 0
+	}
+
+	s.Require().NoError(response.Body.Close())
+
+	return response.StatusCode
+}
+
+type stubDependency struct {
+	err   error
+	delay time.Duration
+}
+
+func (d stubDependency) Ping(ctx context.Context) error {
+	select {
+	case <-time.After(d.delay):
+		return d.err
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
+}
+
+func TestReadinessReportsUnavailableDependencies(t *testing.T) {
+	t.Parallel()
+
+	service := healthservice.New(healthservice.Config{Timeout: 50 * time.Millisecond})
+	service.AddDependency("postgres", stubDependency{})
+	service.AddDependency("redis", stubDependency{err: errors.New("connection refused")}) //nolint:err113
+	service.AddDependency("clickhouse", stubDependency{delay: time.Second})
+
+	recorder := httptest.NewRecorder()
+	healthhandler.New(service).Readiness(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	var report entity.HealthReport
+
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&report))
+	require.Equal(t, entity.HealthStatusDown, report.Status)
+	require.Equal(t, entity.HealthStatusOK, report.Checks["postgres"].Status)
+	require.Equal(t, entity.HealthStatusDown, report.Checks["redis"].Status)
+	require.Equal(t, "connection refused", report.Checks["redis"].Error)
+	require.Equal(t, entity.HealthStatusDown, report.Checks["clickhouse"].Status)
+	require.Contains(t, report.Checks["clickhouse"].Error, context.DeadlineExceeded.Error())
+}
@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *IntegrationTestSuite) TestImport() {
+	importGoods := func(query, body string, status int) entity.ImportResponse {
+		request, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+			fmt.Sprintf("http://localhost:%d/api/v1/goods/import?%s", port, query), strings.NewReader(body))
+		s.Require().NoError(err)
+
+		response, err := http.DefaultClient.Do(request)
+		s.Require().NoError(err)
+
+		defer func() {
+			s.Require().NoError(response.Body.Close())
+		}()
+
+		responseBody, err := io.ReadAll(response.Body)
+		s.Require().NoError(err)
+		s.Require().Equal(status, response.StatusCode, string(responseBody))
+
+		var result entity.ImportResponse
+		if status == http.StatusOK {
+			s.Require().NoError(json.Unmarshal(responseBody, &result))
+		}
+
+		return result
+	}
+
+	listGoods := func() []entity.Good {
+		var response entity.GoodsListResponse
+
+		s.sendRequest(http.MethodGet, "/api/v1/goods/list?projectId=1&limit=100", http.StatusOK, nil, &response)
+
+		slices.SortFunc(response.Goods, func(a, b entity.Good) int {
+			return cmp.Or(cmp.Compare(a.Priority, b.Priority), cmp.Compare(a.ID, b.ID))
+		})
+
+		return response.Goods
+	}
+
+	names := func(goods []entity.Good) []string {
+		result := make([]string, 0, len(goods))
+		for _, good := range goods {
+			result = append(result, good.Name)
+		}
+
+		return result
+	}
+
+	s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+		&entity.GoodCreateRequest{Name: "Existing"}, nil)
+
+	csvFile := "name,description,tags,category_id\n" +
+		"Dress,\"Cotton, light\",\"Summer,promo\",\n" +
+		",No name,,\n" +
+		"Hat,,bad tag!,\n" +
+		"Coat,,,999999\n" +
+		"Scarf,Wool,winter,\n"
+
+	s.Run("dry run saves nothing", func() {
+		response := importGoods("projectId=1&dryRun=true", csvFile, http.StatusOK)
+
+		s.Require().True(response.DryRun)
+		s.Require().Equal(5, response.Total)
+		s.Require().Equal(2, response.Created)
+		s.Require().Len(listGoods(), 1)
+	})
+
+	s.Run("valid rows are inserted and invalid ones reported", func() {
+		response := importGoods("projectId=1", csvFile, http.StatusOK)
+
+		s.Require().Equal(entity.ImportResponse{
+			Total:   5,
+			Created: 2,
+			Failed:  3,
+			Errors: []entity.ImportError{
+				{Line: 3, Error: entity.ErrEmptyName.Error()},
+				{Line: 4, Error: entity.ErrInvalidTag.Error()},
+				{Line: 5, Error: entity.ErrCategoryNotFound.Error()},
+			},
+		}, response)
+
+		goods := listGoods()
+		s.Require().Equal([]string{"Existing", "Dress", "Scarf"}, names(goods))
+		s.Require().Equal([]string{"promo", "summer"}, goods[1].Tags)
+		s.Require().Equal("Cotton, light", goods[1].Description)
+		s.Require().Equal(goods[0].Priority+1, goods[1].Priority)
+		s.Require().Equal(goods[1].Priority+1, goods[2].Priority)
+	})
+
+	s.Run("upsert updates goods by id", func() {
+		goods := listGoods()
+
+		jsonlFile := fmt.Sprintf(`{"id": %d, "name": "Dress v2", "tags": ["sale"], "priority": 1000}`+"\n"+
+			`{"id": 999999, "name": "Unknown"}`+"\n"+
+			"\n"+
+			`{"name": "Gloves"}`+"\n"+
+			`not json`+"\n",
+			goods[1].ID)
+
+		response := importGoods("projectId=1&mode=upsert&format=jsonl", jsonlFile, http.StatusOK)
+
+		s.Require().Equal(4, response.Total)
+		s.Require().Equal(1, response.Created)
+		s.Require().Equal(1, response.Updated)
+		s.Require().Equal(2, response.Failed)
+		s.Require().Equal(2, response.Errors[0].Line)
+		s.Require().Equal(entity.ErrGoodNotFound.Error(), response.Errors[0].Error)
+		s.Require().Equal(5, response.Errors[1].Line)
+
+		var updated entity.Good
+
+		s.sendRequest(http.MethodGet, goodsPath+fmt.Sprintf("/get?id=%d&projectId=1", goods[1].ID), http.StatusOK, nil, &updated)
+		s.Require().Equal("Dress v2", updated.Name)
+		s.Require().Equal([]string{"sale"}, updated.Tags)
+		s.Require().Equal(goods[1].Priority, updated.Priority)
+	})
+
+	s.Run("priorities from the file", func() {
+		response := importGoods("projectId=1&priorities=file", "name,priority\nFirst,0\nLast,500\n", http.StatusOK)
+
+		s.Require().Equal(1, response.Created)
+		s.Require().Equal([]entity.ImportError{{Line: 2, Error: entity.ErrNegativePriority.Error()}}, response.Errors)
+
+		goods := listGoods()
+		s.Require().Equal("Last", goods[len(goods)-1].Name)
+		s.Require().Equal(500, goods[len(goods)-1].Priority)
+	})
+
+	s.Run("invalid imports are rejected", func() {
+		importGoods("projectId=1&mode=merge", csvFile, http.StatusBadRequest)
+		importGoods("projectId=1&format=xml", csvFile, http.StatusBadRequest)
+		importGoods("projectId=1", "title\nDress\n", http.StatusBadRequest)
+		importGoods("projectId=1", "", http.StatusBadRequest)
+		importGoods("mode=insert", csvFile, http.StatusBadRequest)
+	})
+}
@@ -3,26 +3,52 @@ package tests
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/netip"
 	"testing"
 	"time"
 
 	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+	goodsv1 "github.com/romanpitatelev/hezzl-goods/api/goods/v1"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/admin"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/grpcapi"
 	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest"
+	apikeyshandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/apikeys-handler"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/auth"
+	exporthandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/export-handler"
 	goodshandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/goods-handler"
+	healthhandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/health-handler"
+	ratelimit "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/rate-limit"
+	streamhandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/stream-handler"
+	webhookshandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/webhooks-handler"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/metrics"
+	"github.com/romanpitatelev/hezzl-goods/internal/nats/broadcaster"
 	"github.com/romanpitatelev/hezzl-goods/internal/nats/consumer"
 	"github.com/romanpitatelev/hezzl-goods/internal/nats/producer"
+	apikeysrepo "github.com/romanpitatelev/hezzl-goods/internal/repository/apikeys-repo"
 	"github.com/romanpitatelev/hezzl-goods/internal/repository/clickhouse"
 	goodsrepo "github.com/romanpitatelev/hezzl-goods/internal/repository/goods-repo"
 	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
 	"github.com/romanpitatelev/hezzl-goods/internal/repository/redis"
+	webhooksrepo "github.com/romanpitatelev/hezzl-goods/internal/repository/webhooks-repo"
+	apikeysservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/apikeys-service"
+	goodsexport "github.com/romanpitatelev/hezzl-goods/internal/usecase/goods-export"
 	goodsservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/goods-service"
+	healthservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/health-service"
+	webhookdispatcher "github.com/romanpitatelev/hezzl-goods/internal/usecase/webhook-dispatcher"
+	webhooksservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/webhooks-service"
 	"github.com/rs/zerolog/log"
 	migrate "github.com/rubenv/sql-migrate"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 const (
@@ -33,6 +59,16 @@ const (
 	redisPassword = ""
 	redisDB       = 0
 	port          = 5003
+	grpcPort      = 5004
+	authPort      = 5005
+	rateLimitPort = 5006
+	adminPort     = 5007
+	drainPort     = 5008
+	grpcAuthPort  = 5009
+	exportPort    = 5010
+	authLimitPort = 5011
+	adminAPIKey   = "test-admin-key"
+	allowedOrigin = "https://shop.example.com"
 	goodsPath     = "/api/v1/good"
 )
 
@@ -43,11 +79,32 @@ type IntegrationTestSuite struct {
 	clickhouseStore *clickhouse.Store
 	natsClient      *consumer.NATSConsumer
 	natsProducer    *producer.NatsWrapper
+	broadcaster     *broadcaster.Broadcaster
 	redisClient     *redis.Client
 	goodsrepo       *goodsrepo.Repo
 	goodsservice    *goodsservice.Service
+	webhooksrepo    *webhooksrepo.Repo
+	webhookservice  *webhooksservice.Service
+	dispatcher      *webhookdispatcher.Dispatcher
 	goodshandler    *goodshandler.Handler
+	streamhandler   *streamhandler.Handler
+	exporter        *goodsexport.Exporter
+	exporthandler   *exporthandler.Handler
+	webhookshandler *webhookshandler.Handler
+	apikeysservice  *apikeysservice.Service
+	apikeyshandler  *apikeyshandler.Handler
+	healthservice   *healthservice.Service
+	healthhandler   *healthhandler.Handler
 	server          *rest.Server
+	rsaKey          *rsa.PrivateKey
+	ecKey           *ecdsa.PrivateKey
+	jwksFile        string
+	authServer      *rest.Server
+	rateLimitServer *rest.Server
+	grpcServer      *grpcapi.Server
+	adminServer     *admin.Server
+	grpcClient      goodsv1.GoodsServiceClient
+	grpcAuthClient  goodsv1.GoodsServiceClient
 }
 
 func (s *IntegrationTestSuite) SetupSuite() {
@@ -90,7 +147,26 @@ func (s *IntegrationTestSuite) SetupSuite() {
 	err = s.natsClient.Start()
 	s.Require().NoError(err)
 
-	s.goodsrepo = goodsrepo.New(s.db)
+	s.broadcaster = broadcaster.New(nc, "goods.logs")
+	err = s.broadcaster.Start(ctx)
+	s.Require().NoError(err)
+
+	s.goodsrepo = goodsrepo.New(goodsrepo.Config{PriorityScope: entity.PriorityScopeProject}, s.db)
+
+	s.webhooksrepo = webhooksrepo.New(s.db)
+
+	s.dispatcher = webhookdispatcher.New(webhookdispatcher.Config{
+		Subject:             "goods.logs",
+		Workers:             2,
+		MaxAttempts:         3,
+		InitialBackoff:      10 * time.Millisecond,
+		MaxBackoff:          50 * time.Millisecond,
+		Timeout:             time.Second,
+		DisableThreshold:    2,
+		AllowPrivateTargets: true,
+	}, nc, s.webhooksrepo)
+	err = s.dispatcher.Start(ctx)
+	s.Require().NoError(err)
 
 	s.redisClient, err = redis.New(ctx, redisAddr, redisPassword, redisDB)
 	s.Require().NoError(err)
@@ -99,9 +175,40 @@ func (s *IntegrationTestSuite) SetupSuite() {
 
 	s.goodshandler = goodshandler.New(s.goodsservice)
 
+	s.streamhandler = streamhandler.New(streamhandler.Config{
+		AllowedOrigins: []string{allowedOrigin},
+	}, s.broadcaster)
+
+	s.exporter = goodsexport.New(s.goodsrepo)
+
+	s.exporthandler = exporthandler.New(s.exporter)
+
+	s.webhookservice = webhooksservice.New(webhooksservice.Config{AllowPrivateTargets: true}, s.webhooksrepo)
+
+	s.webhookshandler = webhookshandler.New(s.webhookservice)
+
+	s.apikeysservice = apikeysservice.New(apikeysrepo.New(s.db), adminAPIKey)
+
+	s.apikeyshandler = apikeyshandler.New(s.apikeysservice)
+
+	s.healthservice = healthservice.New(healthservice.Config{Timeout: time.Second})
+	s.healthservice.AddDependency("postgres", s.db)
+	s.healthservice.AddOptionalDependency("redis", s.redisClient)
+	s.healthservice.AddOptionalDependency("nats", s.natsProducer)
+	s.healthservice.AddOptionalDependency("clickhouse", s.clickhouseStore)
+
+	s.healthhandler = healthhandler.New(s.healthservice)
+
 	s.server = rest.New(
 		rest.Config{BindAddress: fmt.Sprintf(":%d", port)},
 		s.goodshandler,
+		s.streamhandler,
+		s.exporthandler,
+		s.webhookshandler,
+		s.apikeyshandler,
+		auth.New(auth.Config{Enabled: false}, s.apikeysservice, nil),
+		ratelimit.New(ratelimit.Config{Enabled: false}, nil),
+		s.healthhandler,
 	)
 
 	//nolint:testifylint
@@ -110,6 +217,113 @@ func (s *IntegrationTestSuite) SetupSuite() {
 		s.Require().NoError(err)
 	}()
 
+	jwksFile := s.setupJWTKeys()
+
+	tokenVerifier, err := auth.NewTokenVerifier(ctx, auth.JWTConfig{
+		JWKSSource:      jwksFile,
+		Issuer:          jwtIssuer,
+		ProjectsClaim:   "projects",
+		RolesClaim:      "roles",
+		RefetchInterval: time.Millisecond,
+	})
+	s.Require().NoError(err)
+
+	authMiddleware := auth.New(auth.Config{Enabled: true}, s.apikeysservice, tokenVerifier)
+
+	s.authServer = rest.New(
+		rest.Config{BindAddress: fmt.Sprintf(":%d", authPort)},
+		s.goodshandler,
+		s.streamhandler,
+		s.exporthandler,
+		s.webhookshandler,
+		s.apikeyshandler,
+		authMiddleware,
+		ratelimit.New(ratelimit.Config{Enabled: false}, nil),
+		s.healthhandler,
+	)
+
+	//nolint:testifylint
+	go func() {
+		err = s.authServer.Run(ctx)
+		s.Require().NoError(err)
+	}()
+
+	s.rateLimitServer = rest.New(
+		rest.Config{
+			BindAddress: fmt.Sprintf(":%d", rateLimitPort),
+			TrustedProxies: []netip.Prefix{
+				netip.MustParsePrefix("127.0.0.0/8"),
+				netip.MustParsePrefix("::1/128"),
+			},
+		},
+		s.goodshandler,
+		s.streamhandler,
+		s.exporthandler,
+		s.webhookshandler,
+		s.apikeyshandler,
+		auth.New(auth.Config{Enabled: false}, s.apikeysservice, nil),
+		ratelimit.New(ratelimit.Config{
+			Enabled:     true,
+			Distributed: true,
+			Limits: map[string]ratelimit.Limit{
+				ratelimit.GroupRead: {Rate: 1, Burst: rateLimitBurst},
+			},
+		}, s.redisClient),
+		s.healthhandler,
+	)
+
+	//nolint:testifylint
+	go func() {
+		err = s.rateLimitServer.Run(ctx)
+		s.Require().NoError(err)
+	}()
+
+	s.grpcServer = grpcapi.New(
+		grpcapi.Config{BindAddress: fmt.Sprintf(":%d", grpcPort)},
+		s.goodsservice,
+		nil,
+	)
+
+	//nolint:testifylint
+	go func() {
+		err = s.grpcServer.Run(ctx)
+		s.Require().NoError(err)
+	}()
+
+	prometheus.MustRegister(metrics.NewPoolCollector(s.db))
+
+	s.adminServer = admin.New(admin.Config{BindAddress: fmt.Sprintf(":%d", adminPort)})
+
+	//nolint:testifylint
+	go func() {
+		err = s.adminServer.Run(ctx)
+		s.Require().NoError(err)
+	}()
+
+	grpcConn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", grpcPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+
+	s.grpcClient = goodsv1.NewGoodsServiceClient(grpcConn)
+
+	grpcAuthServer := grpcapi.New(
+		grpcapi.Config{BindAddress: fmt.Sprintf(":%d", grpcAuthPort), AuthEnabled: true},
+		s.goodsservice,
+		authMiddleware,
+	)
+
+	//nolint:testifylint
+	go func() {
+		err = grpcAuthServer.Run(ctx)
+		s.Require().NoError(err)
+	}()
+
+	grpcAuthConn, err := grpc.NewClient(fmt.Sprintf("localhost:%d", grpcAuthPort),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	s.Require().NoError(err)
+
+	s.grpcAuthClient = goodsv1.NewGoodsServiceClient(grpcAuthConn)
+
 	time.Sleep(50 * time.Millisecond)
 }
 
@@ -119,7 +333,12 @@ func (s *IntegrationTestSuite) TearDownSuite() {
 
 func (s *IntegrationTestSuite) TearDownTest() {
 	err := s.db.Truncate(context.Background(),
+		"webhook_deliveries",
+		"webhooks",
+		"api_keys",
 		"goods",
+		"categories",
+		"attribute_schemas",
 	)
 	s.Require().NoError(err)
 
@@ -136,16 +355,34 @@ func TestIntegrationSetupSuite(t *testing.T) {
 }
 
 func (s *IntegrationTestSuite) sendRequest(method, path string, status int, entity, result any) {
+	s.sendRequestWithHeaders(port, method, path, nil, status, entity, result)
+}
+
+func (s *IntegrationTestSuite) sendAuthRequest(method, path, apiKey string, status int, entity, result any) {
+	headers := map[string]string{}
+	if apiKey != "" {
+		headers[auth.APIKeyHeader] = apiKey
+	}
+
+	s.sendRequestWithHeaders(authPort, method, path, headers, status, entity, result)
+}
+
+func (s *IntegrationTestSuite) sendRequestWithHeaders(
+	serverPort int, method, path string, headers map[string]string, status int, entity, result any,
+) {
 	body, err := json.Marshal(entity)
 	s.Require().NoError(err)
 
-	requestURL := fmt.Sprintf("http://localhost:%d%s", port, path)
+	requestURL := fmt.Sprintf("http://localhost:%d%s", serverPort, path)
 	s.T().Logf("Sending request to %s", requestURL)
 
-	request, err := http.NewRequestWithContext(context.Background(), method,
-		fmt.Sprintf("http://localhost:%d%s", port, path), bytes.NewReader(body))
+	request, err := http.NewRequestWithContext(context.Background(), method, requestURL, bytes.NewReader(body))
 	s.Require().NoError(err, "fail to create request")
 
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
 	client := http.Client{}
 
 	response, err := client.Do(request)
@@ -0,0 +1,229 @@
+package tests
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+const (
+	jwtIssuer = "https://sso.test"
+	rsaKeyID  = "rsa-key"
+	ecKeyID   = "ec-key"
+)
+
+// setupJWTKeys generates signing keys and writes their public parts to a JWKS file.
+func (s *IntegrationTestSuite) setupJWTKeys() string {
+	var err error
+
+	s.rsaKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	s.ecKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+
+	s.jwksFile = filepath.Join(s.T().TempDir(), "jwks.json")
+	s.writeJWKS()
+
+	return s.jwksFile
+}
+
+// writeJWKS writes the public parts of the suite's keys and the extra keys to the JWKS file.
+func (s *IntegrationTestSuite) writeJWKS(extra ...map[string]string) {
+	keys := []map[string]string{
+		{
+			"kty": "RSA",
+			"kid": rsaKeyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   encodeJWKValue(s.rsaKey.N),
+			"e":   encodeJWKValue(big.NewInt(int64(s.rsaKey.E))),
+		},
+		{
+			"kty": "EC",
+			"kid": ecKeyID,
+			"use": "sig",
+			"alg": "ES256",
+			"crv": "P-256",
+			"x":   encodeJWKValue(s.ecKey.X),
+			"y":   encodeJWKValue(s.ecKey.Y),
+		},
+	}
+
+	data, err := json.Marshal(map[string]any{"keys": append(keys, extra...)})
+	s.Require().NoError(err)
+
+	s.Require().NoError(os.WriteFile(s.jwksFile, data, 0o600))
+}
+
+func encodeJWKValue(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+func (s *IntegrationTestSuite) signToken(method jwt.SigningMethod, claims jwt.MapClaims) string {
+	if _, ok := claims["iss"]; !ok {
+		claims["iss"] = jwtIssuer
+	}
+
+	if _, ok := claims["exp"]; !ok {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+
+	var key any
+
+	switch method {
+	case jwt.SigningMethodES256:
+		token.Header["kid"] = ecKeyID
+		key = s.ecKey
+	default:
+		token.Header["kid"] = rsaKeyID
+		key = s.rsaKey
+	}
+
+	signed, err := token.SignedString(key)
+	s.Require().NoError(err)
+
+	return signed
+}
+
+func (s *IntegrationTestSuite) sendBearerRequest(method, path, token string, status int, entity, result any) {
+	headers := map[string]string{"Authorization": "Bearer " + token}
+
+	s.sendRequestWithHeaders(authPort, method, path, headers, status, entity, result)
+}
+
+func (s *IntegrationTestSuite) TestJWTAuthentication() {
+	writer := s.signToken(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":      "alice",
+		"projects": []int{1},
+		"roles":    []string{entity.ScopeGoodsWrite},
+	})
+
+	s.Run("RS256 token creates goods and is recorded as actor", func() {
+		for i := range 30 {
+			var createdGood entity.Good
+
+			s.sendBearerRequest(http.MethodPost, "/api/v1/good/create?projectId=1", writer, http.StatusCreated,
+				&entity.GoodCreateRequest{Name: fmt.Sprintf("jwt_%d", i)}, &createdGood)
+		}
+
+		s.Require().Eventually(func() bool {
+			var count int
+
+			err := s.clickhouseStore.DB().QueryRowContext(context.Background(),
+				"SELECT count() FROM goods_logs WHERE actor = 'jwt:alice' AND operation = 'create'").Scan(&count)
+
+			return err == nil && count == 30
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+
+	s.Run("ES256 token with all projects", func() {
+		reader := s.signToken(jwt.SigningMethodES256, jwt.MapClaims{
+			"sub":      "bob",
+			"projects": "*",
+			"roles":    "goods:read",
+		})
+
+		s.sendBearerRequest(http.MethodGet, "/api/v1/goods/list", reader, http.StatusOK, nil, nil)
+		s.sendBearerRequest(http.MethodPost, "/api/v1/good/create?projectId=1", reader, http.StatusForbidden,
+			&entity.GoodCreateRequest{Name: "forbidden"}, nil)
+	})
+
+	s.Run("token for another project", func() {
+		s.sendBearerRequest(http.MethodPost, "/api/v1/good/create?projectId=2", writer, http.StatusForbidden,
+			&entity.GoodCreateRequest{Name: "other project"}, nil)
+	})
+
+	s.Run("expired token", func() {
+		expired := s.signToken(jwt.SigningMethodRS256, jwt.MapClaims{
+			"sub":      "alice",
+			"projects": []int{1},
+			"roles":    []string{entity.ScopeGoodsRead},
+			"exp":      time.Now().Add(-time.Minute).Unix(),
+		})
+
+		s.sendBearerRequest(http.MethodGet, "/api/v1/goods/list?projectId=1", expired, http.StatusUnauthorized, nil, nil)
+	})
+
+	s.Run("token with wrong issuer", func() {
+		token := s.signToken(jwt.SigningMethodRS256, jwt.MapClaims{
+			"sub":      "alice",
+			"iss":      "https://evil.test",
+			"projects": []int{1},
+			"roles":    []string{entity.ScopeGoodsRead},
+		})
+
+		s.sendBearerRequest(http.MethodGet, "/api/v1/goods/list?projectId=1", token, http.StatusUnauthorized, nil, nil)
+	})
+
+	s.Run("token signed with unknown key", func() {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		s.Require().NoError(err)
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"sub": "mallory",
+			"iss": jwtIssuer,
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = rsaKeyID
+
+		signed, err := token.SignedString(otherKey)
+		s.Require().NoError(err)
+
+		s.sendBearerRequest(http.MethodGet, "/api/v1/goods/list?projectId=1", signed, http.StatusUnauthorized, nil, nil)
+	})
+
+	s.Run("rotated key is fetched and unsupported keys are skipped", func() {
+		rotatedKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		s.Require().NoError(err)
+
+		s.writeJWKS(
+			map[string]string{"kty": "OKP", "kid": "ed-key", "use": "sig", "crv": "Ed25519", "x": "AA"},
+			map[string]string{
+				"kty": "RSA",
+				"kid": "rotated-key",
+				"use": "sig",
+				"n":   encodeJWKValue(rotatedKey.N),
+				"e":   encodeJWKValue(big.NewInt(int64(rotatedKey.E))),
+			},
+		)
+
+		defer s.writeJWKS()
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"sub":      "carol",
+			"iss":      jwtIssuer,
+			"exp":      time.Now().Add(time.Hour).Unix(),
+			"projects": []int{1},
+			"roles":    []string{entity.ScopeGoodsRead},
+		})
+		token.Header["kid"] = "rotated-key"
+
+		signed, err := token.SignedString(rotatedKey)
+		s.Require().NoError(err)
+
+		reader := s.signToken(jwt.SigningMethodES256, jwt.MapClaims{
+			"sub":      "carol",
+			"projects": []int{1},
+			"roles":    []string{entity.ScopeGoodsRead},
+		})
+
+		s.sendBearerRequest(http.MethodGet, "/api/v1/goods/list?projectId=1", signed, http.StatusOK, nil, nil)
+		s.sendBearerRequest(http.MethodGet, "/api/v1/goods/list?projectId=1", reader, http.StatusOK, nil, nil)
+	})
+}
@@ -0,0 +1,53 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *IntegrationTestSuite) TestMetrics() {
+	var createdGood entity.Good
+
+	s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+		&entity.GoodCreateRequest{Name: "measured"}, &createdGood)
+
+	getPath := goodsPath + fmt.Sprintf("/get?id=%d&projectId=%d", createdGood.ID, createdGood.ProjectID)
+
+	s.sendRequest(http.MethodGet, getPath, http.StatusOK, nil, nil)
+	s.sendRequest(http.MethodGet, getPath, http.StatusOK, nil, nil)
+	s.sendRequest(http.MethodGet, goodsPath+"/get?id=9999&projectId=1", http.StatusNotFound, nil, nil)
+
+	body := s.scrapeMetrics()
+
+	s.Contains(body, `hezzl_goods_http_request_duration_seconds_count{method="GET",route="/api/v1/good/get",status="200"}`)
+	s.Contains(body, `hezzl_goods_http_request_duration_seconds_count{method="GET",route="/api/v1/good/get",status="404"}`)
+	s.Contains(body, `hezzl_goods_http_request_duration_seconds_count{method="POST",route="/api/v1/good/create",status="201"}`)
+	s.Contains(body, `hezzl_goods_cache_requests_total{cache="good",result="hit"}`)
+	s.Contains(body, `hezzl_goods_cache_requests_total{cache="good",result="miss"}`)
+	s.Contains(body, "hezzl_goods_pgxpool_total_conns")
+	s.Contains(body, "hezzl_goods_clickhouse_insert_errors_total 0")
+}
+
+func (s *IntegrationTestSuite) scrapeMetrics() string {
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet,
+		fmt.Sprintf("http://localhost:%d/metrics", adminPort), nil)
+	s.Require().NoError(err)
+
+	response, err := http.DefaultClient.Do(request)
+	s.Require().NoError(err)
+
+	defer func() {
+		s.Require().NoError(response.Body.Close())
+	}()
+
+	s.Require().Equal(http.StatusOK, response.StatusCode)
+
+	body, err := io.ReadAll(response.Body)
+	s.Require().NoError(err)
+
+	return string(body)
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/app"
+	"github.com/romanpitatelev/hezzl-goods/internal/configs"
+	migrate "github.com/rubenv/sql-migrate"
+	"golang.org/x/sync/errgroup"
+)
+
+func (s *IntegrationTestSuite) TestMigrationStatus() {
+	cfg := &configs.Config{PostgresDSN: pgDSN, ClickHouseDSN: clickhouseDSN}
+
+	var output bytes.Buffer
+
+	s.Require().NoError(app.MigrationStatus(context.Background(), cfg, &output))
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	s.Require().True(strings.HasPrefix(lines[0], "0001_initial_script.sql\t"), lines[0])
+
+	for _, line := range lines {
+		s.Require().Contains(line, "\tapplied ")
+	}
+}
+
+func (s *IntegrationTestSuite) TestMigrations() {
+	ctx := context.Background()
+
+	lastMigration := func() string {
+		statuses, err := s.db.MigrationStatus()
+		s.Require().NoError(err)
+
+		return statuses[len(statuses)-1].ID
+	}
+
+	s.Run("nothing to apply", func() {
+		planned, err := s.db.PlanMigrations(migrate.Up, 0)
+		s.Require().NoError(err)
+		s.Require().Empty(planned)
+	})
+
+	s.Run("dry run prints the SQL without running it", func() {
+		cfg := &configs.Config{PostgresDSN: pgDSN}
+
+		var output bytes.Buffer
+
+		s.Require().NoError(app.Migrate(ctx, cfg, app.MigrateOptions{Direction: migrate.Down, Limit: 1, DryRun: true}, &output))
+		s.Require().True(strings.HasPrefix(output.String(), "-- "+lastMigration()+" (down)\n"), output.String())
+		s.Require().Contains(output.String(), "DROP")
+
+		statuses, err := s.db.MigrationStatus()
+		s.Require().NoError(err)
+
+		for _, status := range statuses {
+			s.Require().NotNil(status.AppliedAt, status.ID)
+		}
+	})
+
+	s.Run("down and up one migration", func() {
+		last := lastMigration()
+
+		rolledBack, err := s.db.MigrateMax(ctx, migrate.Down, 1)
+		s.Require().NoError(err)
+		s.Require().Equal(1, rolledBack)
+
+		planned, err := s.db.PlanMigrations(migrate.Up, 0)
+		s.Require().NoError(err)
+		s.Require().Len(planned, 1)
+		s.Require().Equal(last, planned[0].ID)
+
+		applied, err := s.db.MigrateMax(ctx, migrate.Up, 1)
+		s.Require().NoError(err)
+		s.Require().Equal(1, applied)
+	})
+
+	s.Run("redo", func() {
+		id, err := s.db.RedoMigration(ctx)
+		s.Require().NoError(err)
+		s.Require().Equal(lastMigration(), id)
+	})
+
+	s.Run("down keeps the ClickHouse audit log", func() {
+		cfg := &configs.Config{PostgresDSN: pgDSN, ClickHouseDSN: clickhouseDSN}
+
+		s.Require().NoError(app.Migrate(ctx, cfg, app.MigrateOptions{Direction: migrate.Down}, io.Discard))
+		s.Require().NoError(app.Migrate(ctx, cfg, app.MigrateOptions{Direction: migrate.Up}, io.Discard))
+
+		var count uint64
+
+		err := s.clickhouseStore.DB().QueryRowContext(ctx, `SELECT count() FROM system.tables WHERE name = 'goods_logs'`).
+			Scan(&count)
+		s.Require().NoError(err)
+		s.Require().Equal(uint64(1), count)
+	})
+
+	s.Run("concurrent migrations wait for the lock", func() {
+		s.Require().NoError(s.db.Migrate(migrate.Down))
+
+		var group errgroup.Group
+
+		for range 3 {
+			group.Go(func() error {
+				return s.db.Migrate(migrate.Up)
+			})
+		}
+
+		s.Require().NoError(group.Wait())
+
+		planned, err := s.db.PlanMigrations(migrate.Up, 0)
+		s.Require().NoError(err)
+		s.Require().Empty(planned)
+	})
+}
@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest"
+	apikeyshandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/apikeys-handler"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/auth"
+	exporthandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/export-handler"
+	goodshandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/goods-handler"
+	healthhandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/health-handler"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/openapi"
+	ratelimit "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/rate-limit"
+	streamhandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/stream-handler"
+	webhookshandler "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/webhooks-handler"
+	"github.com/stretchr/testify/require"
+)
+
+type openAPISpec struct {
+	Paths map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+func TestOpenAPISpecDescribesAllRoutes(t *testing.T) {
+	var spec openAPISpec
+
+	require.NoError(t, json.Unmarshal(openapi.Spec(), &spec))
+	require.NotEmpty(t, spec.Paths)
+
+	server := rest.New(rest.Config{},
+		goodshandler.New(nil),
+		streamhandler.New(streamhandler.Config{}, nil),
+		exporthandler.New(nil),
+		webhookshandler.New(nil),
+		apikeyshandler.New(nil),
+		auth.New(auth.Config{}, nil, nil),
+		ratelimit.New(ratelimit.Config{}, nil),
+		healthhandler.New(nil),
+	)
+
+	routesCount := 0
+
+	err := chi.Walk(server.Routes(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routesCount++
+
+		route = strings.TrimSuffix(route, "/")
+
+		operations, ok := spec.Paths[route]
+		if !ok {
+			t.Errorf("route %s is not described in the OpenAPI spec", route)
+
+			return nil
+		}
+
+		if _, ok := operations[strings.ToLower(method)]; !ok {
+			t.Errorf("method %s of route %s is not described in the OpenAPI spec", method, route)
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Positive(t, routesCount)
+}
@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest"
+	"github.com/romanpitatelev/hezzl-goods/internal/controller/rest/auth"
+	ratelimit "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/rate-limit"
+	requestmeta "github.com/romanpitatelev/hezzl-goods/internal/controller/rest/request-meta"
+	"github.com/stretchr/testify/require"
+)
+
+const rateLimitBurst = 3
+
+func (s *IntegrationTestSuite) TestRateLimit() {
+	// The test server trusts loopback proxies, so every client IP has its own bucket.
+	clientIP := fmt.Sprintf("198.51.100.%d", time.Now().UnixNano()%250+1)
+	otherIP := fmt.Sprintf("203.0.113.%d", time.Now().UnixNano()%250+1)
+	path := fmt.Sprintf("http://localhost:%d/api/v1/goods/list?projectId=1", rateLimitPort)
+
+	s.Run("requests within the burst are allowed", func() {
+		for i := range rateLimitBurst {
+			response := s.sendRateLimitedRequest(path, clientIP)
+			s.Require().Equal(http.StatusOK, response.StatusCode)
+			s.Require().Equal(strconv.Itoa(rateLimitBurst), response.Header.Get("X-RateLimit-Limit"))
+			s.Require().Equal(strconv.Itoa(rateLimitBurst-i-1), response.Header.Get("X-RateLimit-Remaining"))
+		}
+	})
+
+	s.Run("request over the burst is rejected", func() {
+		response := s.sendRateLimitedRequest(path, clientIP)
+		s.Require().Equal(http.StatusTooManyRequests, response.StatusCode)
+		s.Require().Equal("1", response.Header.Get("Retry-After"))
+	})
+
+	s.Run("other clients have their own bucket", func() {
+		response := s.sendRateLimitedRequest(path, otherIP)
+		s.Require().Equal(http.StatusOK, response.StatusCode)
+	})
+
+	s.Run("bucket is refilled", func() {
+		time.Sleep(time.Second)
+
+		response := s.sendRateLimitedRequest(path, clientIP)
+		s.Require().Equal(http.StatusOK, response.StatusCode)
+	})
+
+	s.Run("write routes are not limited", func() {
+		writePath := fmt.Sprintf("http://localhost:%d/api/v1/good/remove?id=9999&projectId=1", rateLimitPort)
+
+		for range rateLimitBurst + 1 {
+			request, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, writePath, nil)
+			s.Require().NoError(err)
+
+			request.Header.Set("X-Forwarded-For", clientIP)
+
+			response, err := http.DefaultClient.Do(request)
+			s.Require().NoError(err)
+			s.Require().NoError(response.Body.Close())
+			s.Require().Equal(http.StatusNotFound, response.StatusCode)
+		}
+	})
+}
+
+func (s *IntegrationTestSuite) TestRateLimitAuthentication() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := rest.New(
+		rest.Config{
+			BindAddress:    fmt.Sprintf(":%d", authLimitPort),
+			TrustedProxies: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128")},
+		},
+		s.goodshandler,
+		s.streamhandler,
+		s.exporthandler,
+		s.webhookshandler,
+		s.apikeyshandler,
+		auth.New(auth.Config{Enabled: true}, s.apikeysservice, nil),
+		ratelimit.New(ratelimit.Config{
+			Enabled: true,
+			Limits: map[string]ratelimit.Limit{
+				ratelimit.GroupAuth: {Rate: 1, Burst: rateLimitBurst},
+			},
+		}, nil),
+		s.healthhandler,
+	)
+
+	stopped := make(chan error, 1)
+
+	go func() {
+		stopped <- server.Run(ctx)
+	}()
+
+	s.Require().Eventually(func() bool {
+		return s.probe(authLimitPort) == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+
+	clientIP := fmt.Sprintf("192.0.2.%d", time.Now().UnixNano()%250+1)
+	path := fmt.Sprintf("http://localhost:%d/api/v1/goods/list?projectId=1", authLimitPort)
+
+	send := func() *http.Response {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		s.Require().NoError(err)
+
+		request.Header.Set("X-Forwarded-For", clientIP)
+		request.Header.Set(auth.APIKeyHeader, "invalid-key")
+
+		response, err := http.DefaultClient.Do(request)
+		s.Require().NoError(err)
+		s.Require().NoError(response.Body.Close())
+
+		return response
+	}
+
+	for range rateLimitBurst {
+		s.Require().Equal(http.StatusUnauthorized, send().StatusCode)
+	}
+
+	response := send()
+	s.Require().Equal(http.StatusTooManyRequests, response.StatusCode)
+	s.Require().Equal("1", response.Header.Get("Retry-After"))
+
+	cancel()
+	s.Require().NoError(<-stopped)
+}
+
+func (s *IntegrationTestSuite) sendRateLimitedRequest(path, clientIP string) *http.Response {
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, path, nil)
+	s.Require().NoError(err)
+
+	request.Header.Set("X-Forwarded-For", clientIP)
+
+	response, err := http.DefaultClient.Do(request)
+	s.Require().NoError(err)
+	s.Require().NoError(response.Body.Close())
+
+	return response
+}
+
+func TestRateLimitInMemory(t *testing.T) {
+	t.Parallel()
+
+	limiter := ratelimit.New(ratelimit.Config{
+		Enabled: true,
+		Limits: map[string]ratelimit.Limit{
+			ratelimit.GroupRead: {Rate: 0.5, Burst: 2},
+		},
+	}, nil)
+
+	middleware := requestmeta.New(requestmeta.Config{
+		TrustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+	})
+
+	handler := middleware(limiter.Limit(ratelimit.GroupRead)(
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	))
+
+	send := func(remoteAddr string, headers ...string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/goods/list", nil)
+		request.RemoteAddr = remoteAddr
+
+		for i := 0; i+1 < len(headers); i += 2 {
+			request.Header.Set(headers[i], headers[i+1])
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		return recorder
+	}
+
+	require.Equal(t, http.StatusOK, send("192.0.2.1:1000").Code)
+	require.Equal(t, http.StatusOK, send("192.0.2.1:1000").Code)
+
+	rejected := send("192.0.2.1:1000")
+	require.Equal(t, http.StatusTooManyRequests, rejected.Code)
+	require.Equal(t, "2", rejected.Header().Get("Retry-After"))
+	require.Equal(t, "0", rejected.Header().Get("X-RateLimit-Remaining"))
+
+	require.Equal(t, http.StatusOK, send("192.0.2.2:1000").Code)
+
+	// Unverified keys and headers of untrusted clients don't give a new bucket.
+	require.Equal(t, http.StatusTooManyRequests, send("192.0.2.1:1000", auth.APIKeyHeader, "made-up").Code)
+	require.Equal(t, http.StatusTooManyRequests, send("192.0.2.1:1000", "X-Forwarded-For", "192.0.2.9").Code)
+	require.Equal(t, http.StatusTooManyRequests, send("192.0.2.1:1000", "X-Real-IP", "192.0.2.9").Code)
+
+	// Trusted proxies report the client, skipping other trusted proxies.
+	require.Equal(t, http.StatusOK, send("10.0.0.1:1000", "X-Forwarded-For", "192.0.2.3, 10.0.0.2").Code)
+	require.Equal(t, http.StatusOK, send("10.0.0.1:1000", "X-Forwarded-For", "192.0.2.3, 10.0.0.2").Code)
+	require.Equal(t, http.StatusTooManyRequests, send("10.0.0.5:1000", "X-Forwarded-For", "192.0.2.3").Code)
+	require.Equal(t, http.StatusTooManyRequests, send("10.0.0.5:1000", "X-Forwarded-For", "192.0.2.1").Code)
+}
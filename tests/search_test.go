@@ -0,0 +1,89 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *IntegrationTestSuite) TestSearch() {
+	ctx := entity.ContextWithSystem(context.Background())
+
+	s.Require().NoError(s.goodsrepo.SetSearchLanguage(ctx, "english"))
+
+	defer func() {
+		s.Require().NoError(s.goodsrepo.SetSearchLanguage(ctx, "simple"))
+	}()
+
+	createGood := func(name, description string) entity.Good {
+		var good entity.Good
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: name, Description: &description}, &good)
+
+		return good
+	}
+
+	search := func(query string) entity.SearchResponse {
+		var response entity.SearchResponse
+
+		s.sendRequest(http.MethodGet, "/api/v1/goods/search?projectId=1&q="+url.QueryEscape(query), http.StatusOK, nil, &response)
+
+		return response
+	}
+
+	names := func(response entity.SearchResponse) []string {
+		result := make([]string, 0, len(response.Results))
+		for _, found := range response.Results {
+			result = append(result, found.Good.Name)
+		}
+
+		return result
+	}
+
+	dress := createGood("Summer dress", "Light cotton dress for hot days")
+	createGood("Winter coat", "Warm coat, summer sale -50% & more")
+	removed := createGood("Summer hat", "Straw hat")
+	createGood("Umbrella", "Keeps you dry")
+
+	s.sendRequest(http.MethodDelete, goodsPath+fmt.Sprintf("/remove?id=%d&projectId=1", removed.ID), http.StatusOK, nil, nil)
+
+	s.Run("words in the name rank higher", func() {
+		response := search("summer")
+
+		s.Require().Equal([]string{"Summer dress", "Winter coat"}, names(response))
+		s.Require().Equal(2, response.Meta.Total)
+		s.Require().Greater(response.Results[0].Rank, response.Results[1].Rank)
+	})
+
+	s.Run("word forms are matched", func() {
+		s.Require().Equal([]string{"Summer dress"}, names(search("dresses")))
+		s.Require().Equal([]string{"Summer dress"}, names(search("day")))
+	})
+
+	s.Run("matches are highlighted", func() {
+		response := search("summer")
+
+		s.Require().Equal(dress.ID, response.Results[0].Good.ID)
+		s.Require().Equal("<mark>Summer</mark> dress", response.Results[0].Highlight.Name)
+		s.Require().Contains(response.Results[1].Highlight.Description, "<mark>summer</mark>")
+		s.Require().Contains(response.Results[1].Highlight.Description, "&amp;")
+	})
+
+	s.Run("typos are tolerated", func() {
+		s.Require().Equal([]string{"Umbrella"}, names(search("umbrela")))
+		s.Require().Contains(names(search("sumer dres")), "Summer dress")
+	})
+
+	s.Run("invalid searches are rejected", func() {
+		s.sendRequest(http.MethodGet, "/api/v1/goods/search?projectId=1&q=", http.StatusBadRequest, nil, nil)
+		s.sendRequest(http.MethodGet, "/api/v1/goods/search?q=dress", http.StatusBadRequest, nil, nil)
+	})
+
+	s.Run("unknown languages are rejected", func() {
+		s.Require().ErrorIs(s.goodsrepo.SetSearchLanguage(ctx, "klingon"), entity.ErrUnknownSearchLanguage)
+	})
+}
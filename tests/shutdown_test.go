@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/nats/consumer"
+	"github.com/romanpitatelev/hezzl-goods/internal/nats/producer"
+	webhookdispatcher "github.com/romanpitatelev/hezzl-goods/internal/usecase/webhook-dispatcher"
+)
+
+func (s *IntegrationTestSuite) TestShutdown() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Run("consumer that wasn't started stops", func() {
+		s.Require().NoError(consumer.New(ctx, nil, s.clickhouseStore).Stop(ctx))
+	})
+
+	s.Run("incomplete batch is written on stop", func() {
+		nc, err := nats.Connect(natsURL)
+		s.Require().NoError(err)
+
+		defer nc.Close()
+
+		natsConsumer := consumer.New(ctx, nc, s.clickhouseStore)
+		s.Require().NoError(natsConsumer.Start())
+
+		natsProducer := producer.New(ctx, nc, "goods.logs")
+
+		for i := range 5 {
+			s.Require().NoError(natsProducer.Publish(ctx, "goods.logs", entity.GoodLog{
+				Operation: "update",
+				GoodID:    i + 1,
+				ProjectID: 1,
+				RequestID: "shutdown-flush",
+				EventTime: time.Now(),
+			}))
+		}
+
+		s.Require().NoError(natsProducer.Close())
+
+		stopCtx, stopCancel := context.WithTimeout(ctx, 5*time.Second)
+		defer stopCancel()
+
+		s.Require().NoError(natsConsumer.Stop(stopCtx))
+
+		var count int
+
+		s.Require().NoError(s.clickhouseStore.DB().QueryRowContext(ctx,
+			"SELECT count() FROM goods_logs WHERE request_id = 'shutdown-flush'").Scan(&count))
+		s.Require().GreaterOrEqual(count, 5)
+	})
+
+	s.Run("queued webhook events are delivered on stop", func() {
+		receiver := newWebhookReceiver(http.StatusOK)
+		receiver.delay = 100 * time.Millisecond
+
+		defer receiver.server.Close()
+
+		s.sendRequest(http.MethodPost, "/api/v1/webhook/create?projectId=1", http.StatusCreated,
+			&entity.WebhookCreateRequest{URL: receiver.server.URL}, nil)
+
+		nc, err := nats.Connect(natsURL)
+		s.Require().NoError(err)
+
+		defer nc.Close()
+
+		dispatcherCtx, dispatcherCancel := context.WithCancel(ctx)
+		defer dispatcherCancel()
+
+		dispatcher := webhookdispatcher.New(webhookdispatcher.Config{
+			Subject:             "goods.logs.shutdown",
+			Workers:             1,
+			MaxAttempts:         1,
+			Timeout:             time.Second,
+			AllowPrivateTargets: true,
+		}, nc, s.webhooksrepo)
+		s.Require().NoError(dispatcher.Start(dispatcherCtx))
+
+		natsProducer := producer.New(ctx, nc, "goods.logs.shutdown")
+
+		for i := range 5 {
+			s.Require().NoError(natsProducer.Publish(ctx, "goods.logs.shutdown", entity.GoodLog{
+				Operation: "update",
+				GoodID:    i + 1,
+				ProjectID: 1,
+				EventTime: time.Now(),
+			}))
+		}
+
+		s.Require().NoError(natsProducer.Close())
+
+		s.Require().Eventually(func() bool {
+			return len(receiver.received()) > 0
+		}, 5*time.Second, 10*time.Millisecond)
+
+		dispatcherCancel()
+		dispatcher.Wait()
+
+		s.Require().Len(receiver.received(), 5)
+	})
+}
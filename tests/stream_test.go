@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/nats/broadcaster"
+)
+
+type sseEvent struct {
+	id        string
+	eventType string
+	data      string
+}
+
+func (s *IntegrationTestSuite) TestStreamGoods() {
+	s.Run("receive create and update events over SSE", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		events := s.openSSEStream(ctx, 1, "")
+
+		var createdGood entity.Good
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: "streamed"}, &createdGood)
+
+		event := <-events
+		s.Require().Equal("create", event.eventType)
+
+		var goodLog entity.GoodLog
+		s.Require().NoError(json.Unmarshal([]byte(event.data), &goodLog))
+		s.Require().Equal(createdGood.ID, goodLog.GoodID)
+
+		s.sendRequest(http.MethodPatch, goodsPath+fmt.Sprintf("/update?id=%d&projectId=1", createdGood.ID),
+			http.StatusOK, &entity.GoodUpdate{Name: "streamed updated"}, nil)
+
+		event = <-events
+		s.Require().Equal("update", event.eventType)
+	})
+
+	s.Run("resume with Last-Event-ID", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		events := s.openSSEStream(ctx, 1, "")
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: "first"}, nil)
+
+		first := <-events
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: "second"}, nil)
+
+		second := <-events
+
+		resumed := s.openSSEStream(ctx, 1, first.id)
+
+		event := <-resumed
+		s.Require().Equal(second.id, event.id)
+	})
+
+	s.Run("events of instances with skewed clocks are not skipped on resume", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		events := s.openSSEStream(ctx, 1, "")
+
+		// The second instance's clock is behind, so its log has a smaller ID.
+		now := uint64(time.Now().UnixNano()) //nolint:gosec
+		for i, logID := range []uint64{now, now - uint64(time.Minute)} {
+			s.Require().NoError(s.natsProducer.Publish(ctx, "goods.logs", entity.GoodLog{
+				ID:        logID,
+				Operation: "create",
+				GoodID:    1_000_000 + i,
+				ProjectID: 1,
+				EventTime: time.Now(),
+			}))
+		}
+
+		first := <-events
+		second := <-events
+
+		resumed := s.openSSEStream(ctx, 1, first.id)
+
+		event := <-resumed
+		s.Require().Equal(second.id, event.id)
+
+		var goodLog entity.GoodLog
+		s.Require().NoError(json.Unmarshal([]byte(event.data), &goodLog))
+		s.Require().Equal(1_000_001, goodLog.GoodID)
+	})
+
+	s.Run("resume from a forgotten event resets the stream", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		events := s.openSSEStream(ctx, 1, "1")
+
+		event := <-events
+		s.Require().Equal("reset", event.eventType)
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: "after reset"}, nil)
+
+		event = <-events
+		s.Require().Equal("create", event.eventType)
+	})
+
+	s.Run("receive events over WebSocket", func() {
+		url := fmt.Sprintf("ws://localhost:%d/api/v1/goods/stream?projectId=1", port)
+
+		conn, response, err := websocket.DefaultDialer.Dial(url, nil)
+		s.Require().NoError(err)
+
+		defer func() {
+			s.Require().NoError(response.Body.Close())
+			s.Require().NoError(conn.Close())
+		}()
+
+		var createdGood entity.Good
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: "websocket"}, &createdGood)
+
+		s.Require().NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+
+		var event broadcaster.Event
+		s.Require().NoError(conn.ReadJSON(&event))
+		s.Require().Equal("create", event.Log.Operation)
+		s.Require().Equal(createdGood.ID, event.Log.GoodID)
+	})
+
+	s.Run("WebSocket origins are checked", func() {
+		url := fmt.Sprintf("ws://localhost:%d/api/v1/goods/stream?projectId=1", port)
+
+		conn, response, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {allowedOrigin}})
+		s.Require().NoError(err)
+		s.Require().NoError(response.Body.Close())
+		s.Require().NoError(conn.Close())
+
+		_, response, err = websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://evil.example.com"}})
+		s.Require().ErrorIs(err, websocket.ErrBadHandshake)
+		s.Require().Equal(http.StatusForbidden, response.StatusCode)
+		s.Require().NoError(response.Body.Close())
+	})
+
+	s.Run("stream without project id", func() {
+		s.sendRequest(http.MethodGet, "/api/v1/goods/stream", http.StatusBadRequest, nil, nil)
+	})
+}
+
+func (s *IntegrationTestSuite) openSSEStream(ctx context.Context, projectID int, lastEventID string) <-chan sseEvent {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("http://localhost:%d/api/v1/goods/stream?projectId=%d", port, projectID), nil)
+	s.Require().NoError(err)
+
+	if lastEventID != "" {
+		request.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	response, err := http.DefaultClient.Do(request) //nolint:bodyclose
+	s.Require().NoError(err)
+	s.Require().Equal(http.StatusOK, response.StatusCode)
+
+	events := make(chan sseEvent)
+
+	go func() {
+		defer close(events)
+		defer response.Body.Close()
+
+		scanner := bufio.NewScanner(response.Body)
+
+		var event sseEvent
+
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			switch {
+			case line == "":
+				if event.id != "" {
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+
+				event = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				event.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				event.eventType = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				event.data = strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+
+	return events
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+)
+
+func (s *IntegrationTestSuite) TestTags() {
+	createGood := func(name string, tags ...string) entity.Good {
+		var good entity.Good
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: name, Tags: tags}, &good)
+
+		return good
+	}
+
+	listByTags := func(query string) []string {
+		var list entity.GoodsListResponse
+
+		s.sendRequest(http.MethodGet, "/api/v1/goods/list?projectId=1&"+query, http.StatusOK, nil, &list)
+
+		names := make([]string, 0, len(list.Goods))
+		for _, good := range list.Goods {
+			names = append(names, good.Name)
+		}
+
+		return names
+	}
+
+	summer := createGood("summer", "Seasonal", "promo", "promo")
+	winter := createGood("winter", "seasonal")
+	createGood("plain")
+
+	s.Run("tags are normalized on create", func() {
+		s.Require().Equal([]string{"promo", "seasonal"}, summer.Tags)
+
+		var good entity.Good
+
+		s.sendRequest(http.MethodGet, goodsPath+fmt.Sprintf("/get?id=%d&projectId=1", summer.ID), http.StatusOK, nil, &good)
+		s.Require().Equal([]string{"promo", "seasonal"}, good.Tags)
+	})
+
+	s.Run("invalid tags are rejected", func() {
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusBadRequest,
+			&entity.GoodCreateRequest{Name: "invalid", Tags: []string{"a,b"}}, nil)
+		s.sendRequest(http.MethodGet, "/api/v1/goods/list?projectId=1&tags=promo&tagMode=some", http.StatusBadRequest, nil, nil)
+	})
+
+	s.Run("list filters by any or all tags", func() {
+		s.Require().ElementsMatch([]string{"summer", "winter"}, listByTags("tags=seasonal,promo"))
+		s.Require().ElementsMatch([]string{"summer"}, listByTags("tags=seasonal,promo&tagMode=all"))
+		s.Require().ElementsMatch([]string{"summer", "winter", "plain"}, listByTags(""))
+	})
+
+	s.Run("update replaces tags only when they are set", func() {
+		var good entity.Good
+
+		s.sendRequest(http.MethodPatch, goodsPath+fmt.Sprintf("/update?id=%d&projectId=1", winter.ID), http.StatusOK,
+			&entity.GoodUpdate{Name: "winter"}, &good)
+		s.Require().Equal([]string{"seasonal"}, good.Tags)
+
+		tags := []string{"seasonal", "sale"}
+
+		s.sendRequest(http.MethodPatch, goodsPath+fmt.Sprintf("/update?id=%d&projectId=1", winter.ID), http.StatusOK,
+			&entity.GoodUpdate{Name: "winter", Tags: &tags}, &good)
+		s.Require().Equal([]string{"sale", "seasonal"}, good.Tags)
+		s.Require().ElementsMatch([]string{"winter"}, listByTags("tags=sale"))
+	})
+
+	s.Run("rename changes the tag on all goods", func() {
+		var response entity.TagChangeResponse
+
+		s.sendRequest(http.MethodPatch, "/api/v1/tag/rename?projectId=1", http.StatusOK,
+			&entity.TagRenameRequest{From: "seasonal", To: "season"}, &response)
+		s.Require().Equal(2, response.Updated)
+		s.Require().ElementsMatch([]string{"summer", "winter"}, listByTags("tags=season"))
+		s.Require().Empty(listByTags("tags=seasonal"))
+
+		s.sendRequest(http.MethodPatch, "/api/v1/tag/rename?projectId=1", http.StatusConflict,
+			&entity.TagRenameRequest{From: "promo", To: "sale"}, nil)
+		s.sendRequest(http.MethodPatch, "/api/v1/tag/rename?projectId=1", http.StatusNotFound,
+			&entity.TagRenameRequest{From: "missing", To: "other"}, nil)
+	})
+
+	s.Run("merge combines tags without duplicates", func() {
+		var response entity.TagChangeResponse
+
+		s.sendRequest(http.MethodPost, "/api/v1/tags/merge?projectId=1", http.StatusOK,
+			&entity.TagMergeRequest{Sources: []string{"promo", "sale"}, Target: "season"}, &response)
+		s.Require().Equal(2, response.Updated)
+
+		var tags entity.TagsResponse
+
+		s.sendRequest(http.MethodGet, "/api/v1/tags/list?projectId=1", http.StatusOK, nil, &tags)
+		s.Require().Equal([]entity.Tag{{Name: "season", Goods: 2}}, tags.Tags)
+
+		var good entity.Good
+
+		s.sendRequest(http.MethodGet, goodsPath+fmt.Sprintf("/get?id=%d&projectId=1", summer.ID), http.StatusOK, nil, &good)
+		s.Require().Equal([]string{"season"}, good.Tags)
+	})
+
+	s.Run("tags are saved in the audit log", func() {
+		// The consumer writes to ClickHouse in batches, so read enough goods to flush one.
+		ctx := entity.ContextWithCacheBypass(entity.ContextWithSystem(context.Background()))
+
+		for range 30 {
+			_, err := s.goodsservice.GetGood(ctx, summer.ID, summer.ProjectID)
+			s.Require().NoError(err)
+		}
+
+		s.Require().Eventually(func() bool {
+			var count int
+
+			err := s.clickhouseStore.DB().QueryRowContext(context.Background(), `
+SELECT count() FROM goods_logs
+WHERE id = $1 AND operation = 'update' AND tags = ['season'] AND prev_tags = ['promo', 'season']`,
+				summer.ID).Scan(&count)
+
+			return err == nil && count == 1
+		}, 10*time.Second, 100*time.Millisecond)
+	})
+}
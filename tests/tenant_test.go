@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"github.com/romanpitatelev/hezzl-goods/internal/repository/postgres"
+)
+
+func (s *IntegrationTestSuite) TestTenantIsolation() {
+	ctx := entity.ContextWithSystem(context.Background())
+
+	var secondProjectID int
+
+	err := s.db.GetTXFromContext(ctx).
+		QueryRow(ctx, `INSERT INTO projects (name) VALUES ('tenant') RETURNING id`).
+		Scan(&secondProjectID)
+	s.Require().NoError(err)
+
+	defer func() {
+		_, err := s.db.Exec(ctx, `DELETE FROM goods WHERE project_id = $1`, secondProjectID)
+		s.Require().NoError(err)
+		_, err = s.db.Exec(ctx, `DELETE FROM projects WHERE id = $1`, secondProjectID)
+		s.Require().NoError(err)
+	}()
+
+	first, err := s.goodsrepo.CreateGood(ctx, 1, entity.GoodCreateRequest{Name: "First", Tags: []string{"shared"}})
+	s.Require().NoError(err)
+
+	second, err := s.goodsrepo.CreateGood(ctx, secondProjectID, entity.GoodCreateRequest{Name: "Second", Tags: []string{"shared"}})
+	s.Require().NoError(err)
+
+	tenantCtx := entity.ContextWithPrincipal(ctx, entity.Principal{ID: "tenant", ProjectIDs: []int{secondProjectID}})
+
+	projectIDs := func(ctx context.Context, query string) []int {
+		var ids []int
+
+		err := s.db.WithinTransaction(ctx, func(ctx context.Context, tx postgres.Transaction) error {
+			rows, err := tx.Query(ctx, query)
+			if err != nil {
+				return err
+			}
+
+			ids, err = pgx.CollectRows(rows, pgx.RowTo[int])
+
+			return err
+		})
+		s.Require().NoError(err)
+
+		return ids
+	}
+
+	s.Run("queries without a project filter only see the project", func() {
+		s.Require().Equal([]int{secondProjectID}, projectIDs(tenantCtx, `SELECT DISTINCT project_id FROM goods`))
+
+		goods, meta, err := s.goodsrepo.GetGoods(tenantCtx, entity.ListRequest{Limit: 10})
+		s.Require().NoError(err)
+		s.Require().Equal(1, meta.Total)
+		s.Require().Equal(second.ID, goods[0].ID)
+	})
+
+	s.Run("goods of other projects are not found", func() {
+		_, err := s.goodsrepo.GetGood(tenantCtx, first.ID, 1)
+		s.Require().ErrorIs(err, entity.ErrGoodNotFound)
+
+		goods, _, err := s.goodsrepo.GetGoods(tenantCtx, entity.ListRequest{ProjectID: 1, Limit: 10})
+		s.Require().NoError(err)
+		s.Require().Empty(goods)
+
+		tags, err := s.goodsrepo.GetTags(tenantCtx, 1)
+		s.Require().NoError(err)
+		s.Require().Empty(tags)
+	})
+
+	s.Run("goods of other projects can't be changed", func() {
+		var updated int64
+
+		err := s.db.WithinTransaction(tenantCtx, func(ctx context.Context, tx postgres.Transaction) error {
+			tag, err := tx.Exec(ctx, `UPDATE goods SET name = 'Stolen' WHERE id = $1`, first.ID)
+			updated = tag.RowsAffected()
+
+			return err
+		})
+		s.Require().NoError(err)
+		s.Require().Zero(updated)
+
+		err = s.db.WithinTransaction(tenantCtx, func(ctx context.Context, tx postgres.Transaction) error {
+			_, err := tx.Exec(ctx, `INSERT INTO goods (project_id, name, priority, tags) VALUES (1, 'Planted', 1, '{}')`)
+
+			return err
+		})
+		s.Require().ErrorContains(err, "row-level security")
+
+		_, err = s.goodsrepo.ImportGoods(tenantCtx, entity.ImportRequest{
+			ProjectID:  1,
+			Mode:       entity.ImportModeInsert,
+			Priorities: entity.ImportPrioritiesAppend,
+		}, []entity.ImportRow{{GoodCreateRequest: entity.GoodCreateRequest{Name: "Planted", Tags: []string{}}}})
+		s.Require().ErrorContains(err, "row-level security")
+
+		good, err := s.goodsrepo.GetGood(ctx, first.ID, 1)
+		s.Require().NoError(err)
+		s.Require().Equal("First", good.Name)
+	})
+
+	s.Run("imports of the project are allowed", func() {
+		result, err := s.goodsrepo.ImportGoods(tenantCtx, entity.ImportRequest{
+			ProjectID:  secondProjectID,
+			Mode:       entity.ImportModeInsert,
+			Priorities: entity.ImportPrioritiesAppend,
+		}, []entity.ImportRow{{GoodCreateRequest: entity.GoodCreateRequest{Name: "Imported", Tags: []string{}}}})
+		s.Require().NoError(err)
+		s.Require().Len(result.Created, 1)
+	})
+
+	s.Run("principals of several projects see nothing", func() {
+		ctx := entity.ContextWithPrincipal(ctx, entity.Principal{ID: "several", ProjectIDs: []int{1, secondProjectID}})
+
+		s.Require().Empty(projectIDs(ctx, `SELECT DISTINCT project_id FROM goods`))
+	})
+
+	s.Run("callers without a principal see nothing", func() {
+		s.Require().Empty(projectIDs(context.Background(), `SELECT DISTINCT project_id FROM goods`))
+
+		_, err := s.goodsrepo.GetGood(context.Background(), first.ID, 1)
+		s.Require().ErrorIs(err, entity.ErrGoodNotFound)
+	})
+
+	s.Run("principals of all projects and the service itself see everything", func() {
+		adminCtx := entity.ContextWithPrincipal(context.Background(), entity.Principal{ID: "admin", AllProjects: true})
+
+		query := `SELECT DISTINCT project_id FROM goods ORDER BY project_id`
+
+		s.Require().Equal([]int{1, secondProjectID}, projectIDs(adminCtx, query))
+		s.Require().Equal([]int{1, secondProjectID}, projectIDs(ctx, query))
+	})
+}
@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const (
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceParent = "00-" + traceID + "-00f067aa0ba902b7-01"
+)
+
+func (s *IntegrationTestSuite) TestTracing() {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	defer func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	headers := map[string]string{"traceparent": traceParent}
+
+	// The consumer writes to ClickHouse in batches, so create enough goods to flush.
+	for i := range 60 {
+		s.sendRequestWithHeaders(port, http.MethodPost, goodsPath+"/create?projectId=1", headers,
+			http.StatusCreated, &entity.GoodCreateRequest{Name: fmt.Sprintf("traced_%d", i)}, nil)
+	}
+
+	spansInTrace := func() map[string]bool {
+		names := make(map[string]bool)
+
+		for _, span := range recorder.Ended() {
+			if span.SpanContext().TraceID().String() == traceID {
+				names[span.Name()] = true
+			}
+		}
+
+		return names
+	}
+
+	s.Require().Eventually(func() bool {
+		return spansInTrace()["clickhouse INSERT goods_logs"]
+	}, 5*time.Second, 100*time.Millisecond)
+
+	names := spansInTrace()
+	s.Require().True(names["POST /api/v1/good/create"])
+	s.Require().True(names["postgres transaction"])
+	s.Require().True(names["postgres INSERT"])
+	s.Require().True(names["goods.logs publish"])
+}
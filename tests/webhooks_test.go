@@ -0,0 +1,211 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/romanpitatelev/hezzl-goods/internal/entity"
+	webhookdispatcher "github.com/romanpitatelev/hezzl-goods/internal/usecase/webhook-dispatcher"
+	webhooksservice "github.com/romanpitatelev/hezzl-goods/internal/usecase/webhooks-service"
+)
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	server   *httptest.Server
+	requests []receivedWebhook
+	status   int
+	delay    time.Duration
+}
+
+type receivedWebhook struct {
+	event     string
+	timestamp string
+	signature string
+	body      []byte
+}
+
+func newWebhookReceiver(status int) *webhookReceiver {
+	receiver := &webhookReceiver{status: status}
+
+	receiver.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		time.Sleep(receiver.delay)
+
+		receiver.mu.Lock()
+		receiver.requests = append(receiver.requests, receivedWebhook{
+			event:     r.Header.Get(webhookdispatcher.EventHeader),
+			timestamp: r.Header.Get(webhookdispatcher.TimestampHeader),
+			signature: r.Header.Get(webhookdispatcher.SignatureHeader),
+			body:      body,
+		})
+		receiver.mu.Unlock()
+
+		w.WriteHeader(receiver.status)
+	}))
+
+	return receiver
+}
+
+func (w *webhookReceiver) received() []receivedWebhook {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]receivedWebhook(nil), w.requests...)
+}
+
+func (s *IntegrationTestSuite) TestWebhooks() {
+	s.Run("deliver signed event", func() {
+		receiver := newWebhookReceiver(http.StatusOK)
+		defer receiver.server.Close()
+
+		var webhook entity.Webhook
+
+		s.sendRequest(http.MethodPost, "/api/v1/webhook/create?projectId=1", http.StatusCreated,
+			&entity.WebhookCreateRequest{URL: receiver.server.URL, Secret: "top-secret"}, &webhook)
+		s.Require().True(webhook.Active)
+
+		var createdGood entity.Good
+
+		s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+			&entity.GoodCreateRequest{Name: "webhooked"}, &createdGood)
+
+		s.Require().Eventually(func() bool {
+			return len(receiver.received()) == 1
+		}, 5*time.Second, 20*time.Millisecond)
+
+		request := receiver.received()[0]
+		s.Require().Equal("create", request.event)
+		s.Require().Equal(webhookdispatcher.Sign("top-secret", request.timestamp, request.body), request.signature)
+		s.Require().Contains(string(request.body), fmt.Sprintf(`"goodId":%d`, createdGood.ID))
+
+		s.Require().Eventually(func() bool {
+			var deliveries entity.WebhookDeliveriesResponse
+
+			s.sendRequest(http.MethodGet, fmt.Sprintf("/api/v1/webhook/deliveries?id=%d&projectId=1", webhook.ID),
+				http.StatusOK, nil, &deliveries)
+
+			return len(deliveries.Deliveries) == 1 && deliveries.Deliveries[0].Success
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+
+	s.Run("retry and disable failing webhook", func() {
+		receiver := newWebhookReceiver(http.StatusInternalServerError)
+		defer receiver.server.Close()
+
+		var webhook entity.Webhook
+
+		s.sendRequest(http.MethodPost, "/api/v1/webhook/create?projectId=1", http.StatusCreated,
+			&entity.WebhookCreateRequest{URL: receiver.server.URL}, &webhook)
+		s.Require().NotEmpty(webhook.Secret)
+
+		for i := range 2 {
+			s.sendRequest(http.MethodPost, goodsPath+"/create?projectId=1", http.StatusCreated,
+				&entity.GoodCreateRequest{Name: fmt.Sprintf("failing_%d", i)}, nil)
+		}
+
+		s.Require().Eventually(func() bool {
+			var response entity.WebhooksListResponse
+
+			s.sendRequest(http.MethodGet, "/api/v1/webhooks/list?projectId=1", http.StatusOK, nil, &response)
+
+			for _, w := range response.Webhooks {
+				if w.ID == webhook.ID {
+					return !w.Active && w.DisabledAt != nil
+				}
+			}
+
+			return false
+		}, 5*time.Second, 50*time.Millisecond)
+
+		s.Require().Len(receiver.received(), 6, "two events with three attempts each")
+
+		var deliveries entity.WebhookDeliveriesResponse
+
+		s.sendRequest(http.MethodGet, fmt.Sprintf("/api/v1/webhook/deliveries?id=%d&projectId=1", webhook.ID),
+			http.StatusOK, nil, &deliveries)
+		s.Require().Len(deliveries.Deliveries, 6)
+		s.Require().False(deliveries.Deliveries[0].Success)
+		s.Require().Equal(http.StatusInternalServerError, *deliveries.Deliveries[0].StatusCode)
+
+		var enabled entity.Webhook
+
+		s.sendRequest(http.MethodPatch, fmt.Sprintf("/api/v1/webhook/enable?id=%d&projectId=1", webhook.ID),
+			http.StatusOK, nil, &enabled)
+		s.Require().True(enabled.Active)
+		s.Require().Zero(enabled.FailureCount)
+	})
+
+	s.Run("create webhook with invalid url", func() {
+		s.sendRequest(http.MethodPost, "/api/v1/webhook/create?projectId=1", http.StatusBadRequest,
+			&entity.WebhookCreateRequest{URL: "not a url"}, nil)
+	})
+
+	s.Run("private targets are rejected", func() {
+		service := webhooksservice.New(webhooksservice.Config{}, s.webhooksrepo)
+
+		for _, url := range []string{"http://localhost/hook", "http://127.0.0.1/hook", "http://169.254.169.254/", "http://[::1]/", "http://10.0.0.1/"} {
+			_, err := service.CreateWebhook(context.Background(), 1, entity.WebhookCreateRequest{URL: url})
+			s.Require().ErrorIs(err, entity.ErrWebhookTargetNotPublic, url)
+		}
+
+		receiver := newWebhookReceiver(http.StatusOK)
+		defer receiver.server.Close()
+
+		// Names resolving to loopback addresses are rejected by the dialer.
+		webhook, err := s.webhooksrepo.CreateWebhook(context.Background(), 1, entity.WebhookCreateRequest{
+			URL:    strings.Replace(receiver.server.URL, "127.0.0.1", "localhost", 1),
+			Secret: "secret",
+		})
+		s.Require().NoError(err)
+
+		nc, err := nats.Connect(natsURL)
+		s.Require().NoError(err)
+
+		defer nc.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		dispatcher := webhookdispatcher.New(webhookdispatcher.Config{
+			Subject:     "goods.logs.private",
+			Workers:     1,
+			MaxAttempts: 1,
+			Timeout:     time.Second,
+		}, nc, s.webhooksrepo)
+		s.Require().NoError(dispatcher.Start(ctx))
+
+		data, err := json.Marshal(entity.GoodLog{Operation: "create", GoodID: 1, ProjectID: 1})
+		s.Require().NoError(err)
+		s.Require().NoError(nc.Publish("goods.logs.private", data))
+
+		s.Require().Eventually(func() bool {
+			deliveries, err := s.webhooksrepo.GetDeliveries(context.Background(), webhook.ID, 1)
+
+			return err == nil && len(deliveries) == 1 &&
+				strings.Contains(*deliveries[0].Error, entity.ErrWebhookTargetNotPublic.Error())
+		}, 5*time.Second, 20*time.Millisecond)
+
+		s.Require().Empty(receiver.received())
+	})
+
+	s.Run("remove webhook", func() {
+		var webhook entity.Webhook
+
+		s.sendRequest(http.MethodPost, "/api/v1/webhook/create?projectId=1", http.StatusCreated,
+			&entity.WebhookCreateRequest{URL: "http://localhost:1/hook"}, &webhook)
+
+		path := fmt.Sprintf("/api/v1/webhook/remove?id=%d&projectId=1", webhook.ID)
+
+		s.sendRequest(http.MethodDelete, path, http.StatusNoContent, nil, nil)
+		s.sendRequest(http.MethodDelete, path, http.StatusNotFound, nil, nil)
+	})
+}